@@ -0,0 +1,18 @@
+# List the runbooks that were not changed for a while, e.g. to review
+# them or to generate an index page.
+
+data "wikijs_pages" "runbooks" {
+  tags               = ["runbook"]
+  locale             = "en"
+  path_prefix        = "docs/runbooks/"
+  order_by           = "UPDATED"
+  order_by_direction = "ASC"
+  limit              = 20
+}
+
+output "stale_runbooks" {
+  value = [
+    for page in data.wikijs_pages.runbooks.pages :
+    "${page.path} (${page.updated_at})"
+  ]
+}
@@ -0,0 +1,5 @@
+data "wikijs_version" "current" {}
+
+output "wikijs_version" {
+  value = data.wikijs_version.current.current_version
+}
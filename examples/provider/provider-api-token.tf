@@ -0,0 +1,20 @@
+# Authenticate with an API key instead of a login, e.g. in CI pipelines
+
+terraform {
+  required_providers {
+    wikijs = {
+      source  = "tyclipso/wikijs"
+      version = "~> 1"
+    }
+  }
+}
+
+variable "wikijs_api_token" {
+  type      = string
+  sensitive = true
+}
+
+provider "wikijs" {
+  site_url  = "https://wiki.example.com"
+  api_token = var.wikijs_api_token
+}
@@ -12,8 +12,8 @@ terraform {
   }
 }
 
-# You can generate a random password and send it to the /finalize
-# endpoint after starting a wikijs instance
+# You can generate a random password and complete the setup of a new
+# wikijs instance with it using the wikijs_setup resource
 resource "random_password" "wikijs_admin_password" {
   length = 32
 }
@@ -0,0 +1,5 @@
+# Pages can be imported by their id
+terraform import wikijs_page.oncall 42
+
+# or by locale and path
+terraform import wikijs_page.oncall en/docs/runbooks/oncall
@@ -0,0 +1,37 @@
+# Manage a page, changing path or locale later moves the page and
+# keeps its history. Content kept in files is read with content_file,
+# plans then only show the change of its hash instead of the content.
+
+resource "wikijs_page" "oncall" {
+  path        = "docs/runbooks/oncall"
+  locale      = "en"
+  title       = "On-call"
+  description = "How to handle pages during on-call"
+  tags        = ["runbook"]
+
+  content_file = "${path.module}/oncall.md"
+}
+
+# Existing pages can be adopted with import blocks addressing them by
+# locale and path. Terraform 1.7 and later accept for_each, so a whole
+# wiki can be imported at once. Title, description and tags can be kept
+# in YAML front matter at the top of each file instead.
+
+locals {
+  runbooks = toset(["docs/runbooks/backup", "docs/runbooks/restore"])
+}
+
+import {
+  for_each = local.runbooks
+  to       = wikijs_page.runbook[each.key]
+  id       = "en/${each.key}"
+}
+
+resource "wikijs_page" "runbook" {
+  for_each = local.runbooks
+
+  path   = each.key
+  locale = "en"
+
+  content_file = "${path.module}/${basename(each.key)}.md"
+}
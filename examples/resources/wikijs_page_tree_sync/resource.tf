@@ -0,0 +1,12 @@
+# Publish the handbook kept next to the configuration below
+# /handbook. handbook/team/oncall.md becomes the page
+# handbook/team/oncall, its front matter sets the title and tags.
+
+resource "wikijs_page_tree_sync" "handbook" {
+  source_dir  = "${path.module}/handbook"
+  locale      = "en"
+  path_prefix = "handbook"
+
+  # Delete pages whose file was removed from the directory
+  delete_removed = true
+}
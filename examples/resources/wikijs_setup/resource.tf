@@ -0,0 +1,26 @@
+# Complete the setup wizard of a freshly started wiki.js container and
+# manage the wiki with the created administrator account afterwards.
+
+resource "random_password" "wikijs_admin_password" {
+  length = 32
+}
+
+provider "wikijs" {
+  site_url       = "https://wiki.example.com"
+  email          = "admin@wiki.example.com"
+  password       = random_password.wikijs_admin_password.result
+  wait_for_ready = true
+}
+
+resource "wikijs_setup" "setup" {
+  admin_email    = "admin@wiki.example.com"
+  admin_password = random_password.wikijs_admin_password.result
+  site_url       = "https://wiki.example.com"
+  telemetry      = false
+}
+
+resource "wikijs_api" "api" {
+  enabled = true
+
+  depends_on = [wikijs_setup.setup]
+}
@@ -77,7 +77,7 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	wresp, err := wikijs.GetApiState(ctx, d.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get API State Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get API State Request failed", err)
 		return
 	}
 	data.Enabled = types.BoolValue(wresp.Authentication.ApiState)
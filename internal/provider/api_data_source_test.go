@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccApiDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_api" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_api.test", "enabled", "false"),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.ApiEnabled = true })
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_api.test", "enabled", "true"),
+			},
+		},
+	})
+}
@@ -119,7 +119,7 @@ func (d *apiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	wresp, err := wikijs.GetApiKeys(ctx, d.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get API Keys Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get API Keys Request failed", err)
 		return
 	}
 	for _, k := range wresp.Authentication.ApiKeys {
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccApiKeysDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_api_keys" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_api_keys.test", "api_keys.#", "0"),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.ApiKeys = append(store.ApiKeys, &wikijstest.ApiKey{Id: 100, Name: "ci", KeyShort: "...abc", Expiration: "2030-01-01T00:00:00Z"})
+					})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_api_keys.test", "api_keys.#", "1"),
+					resource.TestCheckResourceAttr("data.wikijs_api_keys.test", "api_keys.0.id", "100"),
+					resource.TestCheckResourceAttr("data.wikijs_api_keys.test", "api_keys.0.name", "ci"),
+					resource.TestCheckResourceAttr("data.wikijs_api_keys.test", "api_keys.0.is_revoked", "false"),
+				),
+			},
+		},
+	})
+}
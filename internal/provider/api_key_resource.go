@@ -22,8 +22,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &apiKeyResource{}
-	_ resource.ResourceWithConfigure = &apiKeyResource{}
+	_ resource.Resource                = &apiKeyResource{}
+	_ resource.ResourceWithConfigure   = &apiKeyResource{}
+	_ resource.ResourceWithImportState = &apiKeyResource{}
 )
 
 // NewApiKeyResource is a helper function to simplify the provider implementation.
@@ -171,19 +172,15 @@ func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	wresp, err := wikijs.CreateApiKey(ctx, r.client.graphql, data.Name.ValueString(), data.ExpiresIn.ValueString(), data.FullAccess.ValueBool(), int(data.GroupId.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("Create API Key Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.CreateApiKey.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not create wiki.js API Key: %s", wresp.Authentication.CreateApiKey.ResponseResult.Slug), wresp.Authentication.CreateApiKey.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.CreateApiKey.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not create wiki.js API Key", err)
 		return
 	}
 	data.Key = types.StringValue(wresp.Authentication.CreateApiKey.Key)
 
 	wresp2, err := wikijs.GetApiKeys(ctx, r.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get API Keys Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get API Keys Request failed", err)
 		return
 	}
 	for _, k := range wresp2.Authentication.ApiKeys {
@@ -214,7 +211,7 @@ func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	wresp, err := wikijs.GetApiKeys(ctx, r.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get API Keys Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get API Keys Request failed", err)
 		return
 	}
 	for _, k := range wresp.Authentication.ApiKeys {
@@ -273,12 +270,12 @@ func (r *apiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	wresp, err := wikijs.RevokeApiKey(ctx, r.client.graphql, int(data.Id.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("Delete Wiki.js API Key Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.RevokeApiKey.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not revoke Wiki.js api key: %s", wresp.Authentication.RevokeApiKey.ResponseResult.Slug), wresp.Authentication.RevokeApiKey.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.RevokeApiKey.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not revoke Wiki.js api key", err)
 		return
 	}
 }
+
+func (r *apiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importIntId(ctx, path.Root("id"), req, resp)
+}
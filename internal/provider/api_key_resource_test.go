@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccApiKeyResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApiKeyResourceConfig(srv, "ci"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("wikijs_api_key.test", "id"),
+					resource.TestCheckResourceAttrSet("wikijs_api_key.test", "key"),
+					resource.TestCheckResourceAttrSet("wikijs_api_key.test", "expiration"),
+					resource.TestCheckResourceAttrSet("wikijs_api_key.test", "created_at"),
+					resource.TestCheckResourceAttrWith("wikijs_api_key.test", "key_short", func(value string) error {
+						if len(value) != 23 {
+							return fmt.Errorf("expected three dots and 20 characters, got %q", value)
+						}
+						return nil
+					}),
+					testAccCheckApiKeys(srv, 1),
+				),
+			},
+			{
+				ResourceName:      "wikijs_api_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// wiki.js never returns the key or how it was created
+				ImportStateVerifyIgnore: []string{"key", "expires_in", "full_access", "group_id"},
+			},
+			{
+				// Any change replaces the key
+				Config: testAccApiKeyResourceConfig(srv, "deploy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_api_key.test", "name", "deploy"),
+					testAccCheckApiKeys(srv, 1),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						for _, k := range store.ApiKeys {
+							k.IsRevoked = true
+						}
+					})
+				},
+				Config: testAccApiKeyResourceConfig(srv, "deploy"),
+				Check:  testAccCheckApiKeys(srv, 1),
+			},
+		},
+		CheckDestroy: testAccCheckApiKeys(srv, 0),
+	})
+}
+
+func testAccApiKeyResourceConfig(srv *wikijstest.Server, name string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_api_key" "test" {
+  name        = %q
+  full_access = true
+  expires_in  = "30d"
+}
+`, name)
+}
+
+// testAccCheckApiKeys checks the number of API keys that are not revoked.
+func testAccCheckApiKeys(srv *wikijstest.Server, expected int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var active int
+		srv.Update(func(store *wikijstest.Store) {
+			for _, k := range store.ApiKeys {
+				if !k.IsRevoked {
+					active++
+				}
+			}
+		})
+		if active != expected {
+			return fmt.Errorf("expected %d active API keys, got %d", expected, active)
+		}
+		return nil
+	}
+}
@@ -12,8 +12,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &apiResource{}
-	_ resource.ResourceWithConfigure = &apiResource{}
+	_ resource.Resource                = &apiResource{}
+	_ resource.ResourceWithConfigure   = &apiResource{}
+	_ resource.ResourceWithImportState = &apiResource{}
 )
 
 // NewApiResource is a helper function to simplify the provider implementation.
@@ -76,12 +77,8 @@ func (r *apiResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	wresp, err := wikijs.SetApiState(ctx, r.client.graphql, data.Enabled.ValueBool())
-	if err != nil {
-		resp.Diagnostics.AddError("Set API State Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.SetApiState.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not change api state: %s", wresp.Authentication.SetApiState.ResponseResult.Slug), wresp.Authentication.SetApiState.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.SetApiState.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not change api state", err)
 		return
 	}
 
@@ -99,7 +96,7 @@ func (r *apiResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	wresp, err := wikijs.GetApiState(ctx, r.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get API State Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get API State Request failed", err)
 		return
 	}
 	data.Enabled = types.BoolValue(wresp.Authentication.ApiState)
@@ -117,12 +114,8 @@ func (r *apiResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	wresp, err := wikijs.SetApiState(ctx, r.client.graphql, data.Enabled.ValueBool())
-	if err != nil {
-		resp.Diagnostics.AddError("Set API State Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.SetApiState.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not change api state: %s", wresp.Authentication.SetApiState.ResponseResult.Slug), wresp.Authentication.SetApiState.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.SetApiState.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not change api state", err)
 		return
 	}
 
@@ -139,13 +132,13 @@ func (r *apiResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	}
 
 	wresp, err := wikijs.SetApiState(ctx, r.client.graphql, false)
-	if err != nil {
-		resp.Diagnostics.AddError("Set API State Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.SetApiState.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not change api state: %s", wresp.Authentication.SetApiState.ResponseResult.Slug), wresp.Authentication.SetApiState.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.SetApiState.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not change api state", err)
 		return
 	}
 	resp.Diagnostics.AddWarning("Wiki.JS API disabled", "Deleting the wikijs_api terraform resource disableds the wiki.js API as a security precaution.")
 }
+
+func (r *apiResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
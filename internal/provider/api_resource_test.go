@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccApiResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApiResourceConfig(srv, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_api.test", "enabled", "true"),
+					testAccCheckApiEnabled(srv, true),
+				),
+			},
+			{
+				ResourceName:      "wikijs_api.test",
+				ImportState:       true,
+				ImportStateId:     "api",
+				ImportStateVerify: true,
+				// Singletons have no id, compare any attribute instead
+				ImportStateVerifyIdentifierAttribute: "enabled",
+			},
+			{
+				Config: testAccApiResourceConfig(srv, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_api.test", "enabled", "false"),
+					testAccCheckApiEnabled(srv, false),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.ApiEnabled = true })
+				},
+				Config: testAccApiResourceConfig(srv, false),
+				Check:  testAccCheckApiEnabled(srv, false),
+			},
+		},
+		// The API is switched off again on destroy
+		CheckDestroy: testAccCheckApiEnabled(srv, false),
+	})
+}
+
+func testAccApiResourceConfig(srv *wikijstest.Server, enabled bool) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_api" "test" {
+  enabled = %t
+}
+`, enabled)
+}
+
+func testAccCheckApiEnabled(srv *wikijstest.Server, enabled bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var actual bool
+		srv.Update(func(store *wikijstest.Store) { actual = store.ApiEnabled })
+		if actual != enabled {
+			return fmt.Errorf("expected API enabled to be %t, got %t", enabled, actual)
+		}
+		return nil
+	}
+}
@@ -123,7 +123,7 @@ func (d *authStrategiesDataSource) Read(ctx context.Context, req datasource.Read
 
 	wresp, err := wikijs.GetAuthStrategies(ctx, d.client.graphql, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Get Authentication Strategies Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Authentication Strategies Request failed", err)
 		return
 	}
 
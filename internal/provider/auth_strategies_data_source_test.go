@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccAuthStrategiesDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_auth_strategies" "test" {
+  strategies = []
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_auth_strategies.test", "strategies.#", "1"),
+					resource.TestCheckResourceAttr("data.wikijs_auth_strategies.test", "strategies.0.key", "local"),
+					resource.TestCheckResourceAttr("data.wikijs_auth_strategies.test", "strategies.0.enabled", "true"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.Strategies = append(store.Strategies, &wikijstest.AuthStrategy{
+							Key:              "sso",
+							StrategyKey:      "github",
+							DisplayName:      "GitHub",
+							Order:            1,
+							Config:           map[string]interface{}{"clientId": "wiki"},
+							DomainWhitelist:  []string{},
+							AutoEnrollGroups: []int{},
+						})
+					})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_auth_strategies.test", "strategies.#", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_auth_strategies.test", "strategies.1.strategy_key", "github"),
+					resource.TestCheckResourceAttr("data.wikijs_auth_strategies.test", "strategies.1.config.clientId", "wiki"),
+				),
+			},
+		},
+	})
+}
@@ -23,8 +23,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &authStrategiesResource{}
-	_ resource.ResourceWithConfigure = &authStrategiesResource{}
+	_ resource.Resource                = &authStrategiesResource{}
+	_ resource.ResourceWithConfigure   = &authStrategiesResource{}
+	_ resource.ResourceWithImportState = &authStrategiesResource{}
 )
 
 // NewAuthStrategiesResource is a helper function to simplify the provider implementation.
@@ -243,12 +244,8 @@ func (r *authStrategiesResource) Create(ctx context.Context, req resource.Create
 	}
 
 	wresp, err := wikijs.SetAuthStrategies(ctx, r.client.graphql, strategies)
-	if err != nil {
-		resp.Diagnostics.AddError("Set Authentication Strategies Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.UpdateStrategies.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update authentication strategies: %s", wresp.Authentication.UpdateStrategies.ResponseResult.Slug), wresp.Authentication.UpdateStrategies.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.UpdateStrategies.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update authentication strategies", err)
 		return
 	}
 
@@ -266,7 +263,7 @@ func (r *authStrategiesResource) Read(ctx context.Context, req resource.ReadRequ
 
 	wresp, err := wikijs.GetAuthStrategies(ctx, r.client.graphql, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Get Authentication Strategies Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Authentication Strategies Request failed", err)
 		return
 	}
 
@@ -345,12 +342,8 @@ func (r *authStrategiesResource) Update(ctx context.Context, req resource.Update
 	}
 
 	wresp, err := wikijs.SetAuthStrategies(ctx, r.client.graphql, strategies)
-	if err != nil {
-		resp.Diagnostics.AddError("Set Authentication Strategies Request failed", err.Error())
-		return
-	}
-	if !wresp.Authentication.UpdateStrategies.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update authentication strategies: %s", wresp.Authentication.UpdateStrategies.ResponseResult.Slug), wresp.Authentication.UpdateStrategies.ResponseResult.Message)
+	if err := responseError(err, &wresp.Authentication.UpdateStrategies.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update authentication strategies", err)
 		return
 	}
 
@@ -361,3 +354,7 @@ func (r *authStrategiesResource) Update(ctx context.Context, req resource.Update
 func (r *authStrategiesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	resp.Diagnostics.AddWarning("Not changing auth strategies", "Deleting the wikijs_auth_strategies resource just removes the resource from the terraform state. No wiki.js config is changed.")
 }
+
+func (r *authStrategiesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccAuthStrategiesResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthStrategiesResourceConfig(srv, "Company SSO"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_auth_strategies.test", "strategies.#", "2"),
+					resource.TestCheckResourceAttr("wikijs_auth_strategies.test", "strategies.0.key", "local"),
+					resource.TestCheckResourceAttr("wikijs_auth_strategies.test", "strategies.1.config.clientId", "wiki"),
+					resource.TestCheckResourceAttr("wikijs_auth_strategies.test", "strategies.1.auto_enroll_groups.0", "2"),
+					testAccCheckAuthStrategy(srv, "sso", "Company SSO"),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_auth_strategies.test",
+				ImportState:                          true,
+				ImportStateId:                        "auth_strategies",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "strategies.0.key",
+			},
+			{
+				Config: testAccAuthStrategiesResourceConfig(srv, "Keycloak"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_auth_strategies.test", "strategies.1.display_name", "Keycloak"),
+					testAccCheckAuthStrategy(srv, "sso", "Keycloak"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.Strategies[1].DisplayName = "Renamed" })
+				},
+				Config: testAccAuthStrategiesResourceConfig(srv, "Keycloak"),
+				Check:  testAccCheckAuthStrategy(srv, "sso", "Keycloak"),
+			},
+		},
+		// The strategies are left alone on destroy
+		CheckDestroy: testAccCheckAuthStrategy(srv, "sso", "Keycloak"),
+	})
+}
+
+func testAccAuthStrategiesResourceConfig(srv *wikijstest.Server, displayName string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_auth_strategies" "test" {
+  strategies = [
+    {
+      strategy_key = "local"
+      display_name = "Local"
+      config       = {}
+    },
+    {
+      key                = "sso"
+      strategy_key       = "oidc"
+      display_name       = %q
+      self_registration  = true
+      domain_whitelist   = ["example.com"]
+      auto_enroll_groups = [2]
+      config = {
+        clientId     = "wiki"
+        clientSecret = "secret"
+      }
+    },
+  ]
+}
+`, displayName)
+}
+
+func testAccCheckAuthStrategy(srv *wikijstest.Server, key, displayName string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var actual *wikijstest.AuthStrategy
+		srv.Update(func(store *wikijstest.Store) {
+			for _, s := range store.Strategies {
+				if s.Key == key {
+					copied := *s
+					actual = &copied
+				}
+			}
+		})
+		if actual == nil {
+			return fmt.Errorf("auth strategy %s does not exist", key)
+		}
+		if actual.DisplayName != displayName {
+			return fmt.Errorf("expected display name %q, got %q", displayName, actual.DisplayName)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// errorKind sorts failed wiki.js requests by what the user can do about them.
+type errorKind int
+
+const (
+	// errorKindTransport means wiki.js could not be reached or did not answer
+	// with a GraphQL response.
+	errorKindTransport errorKind = iota
+	// errorKindGraphQL covers all other errors reported by wiki.js.
+	errorKindGraphQL
+	// errorKindAuth means the login failed or the account lacks permissions.
+	errorKindAuth
+	// errorKindNotFound means the requested object does not exist (anymore).
+	errorKindNotFound
+	// errorKindValidation means wiki.js rejected the submitted values.
+	errorKindValidation
+)
+
+// Error codes of wiki.js, see server/helpers/error.js in the wiki.js sources.
+const (
+	errorCodeInputInvalid        = 1012
+	errorCodePageDuplicateCreate = 6002
+	errorCodePageNotFound        = 6003
+	errorCodePageEmptyContent    = 6004
+	errorCodePageIllegalPath     = 6005
+	errorCodePagePathCollision   = 6006
+	errorCodePageCreateForbidden = 6007
+	errorCodePageViewForbidden   = 6013
+)
+
+// errorAttributes maps error codes to the attribute holding the rejected value.
+var errorAttributes = map[int]path.Path{
+	errorCodePageDuplicateCreate: path.Root("path"),
+	errorCodePageEmptyContent:    path.Root("content"),
+	errorCodePageIllegalPath:     path.Root("path"),
+	errorCodePagePathCollision:   path.Root("path"),
+}
+
+// wikijsError is a failed wiki.js request sorted into an errorKind.
+type wikijsError struct {
+	kind    errorKind
+	code    int
+	slug    string
+	message string
+	// attribute is the attribute the error refers to, empty if unknown
+	attribute path.Path
+	err       error
+}
+
+func (e *wikijsError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	if e.slug != "" {
+		return fmt.Sprintf("%s: %s", e.slug, e.message)
+	}
+
+	return e.message
+}
+
+func (e *wikijsError) Unwrap() error {
+	return e.err
+}
+
+// responseResult is the status every wiki.js mutation returns.
+type responseResult interface {
+	GetSucceeded() bool
+	GetErrorCode() int
+	GetSlug() string
+	GetMessage() string
+}
+
+// responseError returns the error of a wiki.js request as *wikijsError. Pass
+// the responseResult of mutations to also check whether wiki.js applied them,
+// nil otherwise. It returns nil if the request succeeded.
+func responseError(err error, result responseResult) error {
+	if err != nil {
+		return classifyError(err)
+	}
+	if result == nil || result.GetSucceeded() {
+		return nil
+	}
+
+	return &wikijsError{
+		kind:      errorKindForCode(result.GetErrorCode()),
+		code:      result.GetErrorCode(),
+		slug:      result.GetSlug(),
+		message:   result.GetMessage(),
+		attribute: errorAttributes[result.GetErrorCode()],
+	}
+}
+
+// classifyError sorts the error returned by a genqlient request.
+func classifyError(err error) *wikijsError {
+	var wErr *wikijsError
+	if errors.As(err, &wErr) {
+		return wErr
+	}
+
+	var list gqlerror.List
+	if !errors.As(err, &list) {
+		return &wikijsError{kind: errorKindTransport, err: err}
+	}
+
+	for _, e := range list {
+		code := gqlErrorCode(e)
+		kind := errorKindForCode(code)
+		switch {
+		case kind != errorKindGraphQL:
+		case e.Message == "Forbidden":
+			kind = errorKindAuth
+		case e.Message == "This page does not exist.":
+			kind = errorKindNotFound
+		default:
+			continue
+		}
+
+		return &wikijsError{kind: kind, code: code, message: e.Message, attribute: errorAttributes[code], err: err}
+	}
+
+	return &wikijsError{kind: errorKindGraphQL, err: err}
+}
+
+// gqlErrorCode returns the wiki.js error code apollo passes on in the
+// extensions of a GraphQL error, 0 if there is none.
+func gqlErrorCode(e *gqlerror.Error) int {
+	exception, ok := e.Extensions["exception"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	code, ok := exception["code"].(float64)
+	if !ok {
+		return 0
+	}
+
+	return int(code)
+}
+
+func errorKindForCode(code int) errorKind {
+	switch {
+	case code == errorCodePageNotFound:
+		return errorKindNotFound
+	case code == errorCodeInputInvalid,
+		code == errorCodePageDuplicateCreate,
+		code == errorCodePageEmptyContent,
+		code == errorCodePageIllegalPath,
+		code == errorCodePagePathCollision:
+		return errorKindValidation
+	case code > 1000 && code < 2000,
+		code >= errorCodePageCreateForbidden && code <= errorCodePageViewForbidden:
+		return errorKindAuth
+	}
+
+	return errorKindGraphQL
+}
+
+// isNotFound reports whether err means that the requested object does not exist.
+func isNotFound(err error) bool {
+	return err != nil && classifyError(err).kind == errorKindNotFound
+}
+
+// isForbidden reports whether wiki.js refused the request because the account
+// lacks a permission.
+func isForbidden(err error) bool {
+	if err == nil {
+		return false
+	}
+	wErr := classifyError(err)
+
+	return wErr.kind == errorKindAuth && wErr.message == "Forbidden"
+}
+
+// addErrorDiagnostic adds err to the diagnostics, on the attribute it refers
+// to if known. The summary describes the failed action, e.g. "Could not create
+// page".
+func addErrorDiagnostic(diags *diag.Diagnostics, summary string, err error) {
+	wErr := classifyError(err)
+
+	detail := wErr.Error()
+	if wErr.slug != "" {
+		summary = fmt.Sprintf("%s: %s", summary, wErr.slug)
+		detail = wErr.message
+	}
+	if wErr.kind == errorKindAuth {
+		detail += "\n\nCheck the provider credentials and the permissions of the wiki.js account."
+	}
+
+	if len(wErr.attribute.Steps()) > 0 {
+		diags.AddAttributeError(wErr.attribute, summary, detail)
+	} else {
+		diags.AddError(summary, detail)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type testResponseResult struct {
+	succeeded bool
+	errorCode int
+	slug      string
+	message   string
+}
+
+func (r *testResponseResult) GetSucceeded() bool { return r.succeeded }
+func (r *testResponseResult) GetErrorCode() int  { return r.errorCode }
+func (r *testResponseResult) GetSlug() string    { return r.slug }
+func (r *testResponseResult) GetMessage() string { return r.message }
+
+func TestResponseError(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		result    responseResult
+		kind      errorKind
+		attribute path.Path
+	}{
+		"transport": {
+			err:  errors.New("connection refused"),
+			kind: errorKindTransport,
+		},
+		"graphql": {
+			err:  gqlerror.List{{Message: "Cannot query field"}},
+			kind: errorKindGraphQL,
+		},
+		"forbidden": {
+			err:  fmt.Errorf("wrapped: %w", gqlerror.List{{Message: "Forbidden"}}),
+			kind: errorKindAuth,
+		},
+		"page not found by code": {
+			err:  gqlerror.List{{Message: "gone", Extensions: map[string]interface{}{"exception": map[string]interface{}{"code": float64(6003)}}}},
+			kind: errorKindNotFound,
+		},
+		"page not found by message": {
+			err:  gqlerror.List{{Message: "This page does not exist."}},
+			kind: errorKindNotFound,
+		},
+		"login failed": {
+			result: &testResponseResult{errorCode: 1002, slug: "AuthLoginFailed"},
+			kind:   errorKindAuth,
+		},
+		"illegal path": {
+			result:    &testResponseResult{errorCode: 6005, slug: "PageIllegalPath"},
+			kind:      errorKindValidation,
+			attribute: path.Root("path"),
+		},
+		"unknown code": {
+			result: &testResponseResult{errorCode: 7001, slug: "SystemGenericError"},
+			kind:   errorKindGraphQL,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var wErr *wikijsError
+			if err := responseError(test.err, test.result); !errors.As(err, &wErr) {
+				t.Fatalf("expected *wikijsError, got %T", err)
+			}
+			if wErr.kind != test.kind {
+				t.Errorf("expected kind %d, got %d", test.kind, wErr.kind)
+			}
+			if !wErr.attribute.Equal(test.attribute) {
+				t.Errorf("expected attribute %q, got %q", test.attribute, wErr.attribute)
+			}
+		})
+	}
+}
+
+func TestResponseErrorSucceeded(t *testing.T) {
+	if err := responseError(nil, &testResponseResult{succeeded: true}); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	if err := responseError(nil, nil); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+}
+
+func TestAddErrorDiagnosticAttribute(t *testing.T) {
+	var diags diag.Diagnostics
+	addErrorDiagnostic(&diags, "Could not create page", responseError(nil, &testResponseResult{errorCode: 6002, slug: "PageDuplicateCreate", message: "Cannot create this page because an entry already exists at the same path."}))
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	if diags[0].Summary() != "Could not create page: PageDuplicateCreate" {
+		t.Errorf("unexpected summary %q", diags[0].Summary())
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("path")) {
+		t.Errorf("expected diagnostic on attribute path")
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	if !isNotFound(gqlerror.List{{Message: "This page does not exist."}}) {
+		t.Errorf("expected unclassified GraphQL error to be not found")
+	}
+	if !isNotFound(responseError(nil, &testResponseResult{errorCode: 6003, slug: "PageNotFound"})) {
+		t.Errorf("expected response result to be not found")
+	}
+	if isNotFound(nil) || isNotFound(errors.New("connection refused")) {
+		t.Errorf("expected other errors not to be not found")
+	}
+}
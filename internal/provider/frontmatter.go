@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v2"
+)
+
+// frontMatter holds the page attributes of a YAML front matter block at the
+// top of the page content. Keys are named like the attributes, other keys
+// are ignored.
+type frontMatter struct {
+	Title            *string  `yaml:"title"`
+	Description      *string  `yaml:"description"`
+	Tags             []string `yaml:"tags"`
+	IsPrivate        *bool    `yaml:"is_private"`
+	PublishStartDate *string  `yaml:"publish_start_date"`
+	PublishEndDate   *string  `yaml:"publish_end_date"`
+	ScriptCss        *string  `yaml:"script_css"`
+	ScriptJs         *string  `yaml:"script_js"`
+}
+
+// splitFrontMatter separates a front matter block delimited by --- lines from
+// the content. Content without front matter is returned unchanged with a nil
+// frontMatter.
+func splitFrontMatter(content string) (*frontMatter, string, error) {
+	first, rest, ok := cutLine(content)
+	if !ok || first != "---" {
+		return nil, content, nil
+	}
+
+	var block strings.Builder
+	for {
+		line, next, ok := cutLine(rest)
+		if line == "---" || line == "..." {
+			fm := &frontMatter{}
+			if err := yaml.Unmarshal([]byte(block.String()), fm); err != nil {
+				return nil, content, fmt.Errorf("could not parse front matter: %w", err)
+			}
+			return fm, next, nil
+		}
+		if !ok {
+			return nil, content, fmt.Errorf("front matter is not closed with a --- line")
+		}
+		block.WriteString(line + "\n")
+		rest = next
+	}
+}
+
+// contentBody returns content without its front matter. Content with front
+// matter that cannot be parsed is returned unchanged.
+func contentBody(content string) string {
+	_, body, _ := splitFrontMatter(content)
+	return body
+}
+
+// cutLine returns the first line of text without its line ending. ok is false
+// if text has no line ending.
+func cutLine(text string) (line string, rest string, ok bool) {
+	line, rest, ok = strings.Cut(text, "\n")
+	return strings.TrimSuffix(line, "\r"), rest, ok
+}
+
+// merge fills the attributes of plan that are not set in config with the
+// values of the front matter. Attributes set in both places with different
+// values keep the configured value and produce a warning.
+func (fm *frontMatter) merge(ctx context.Context, config *pageResourceModel, plan *pageResourceModel, diags *diag.Diagnostics) {
+	mergeFrontMatterString(path.Root("title"), fm.Title, config.Title, &plan.Title, diags)
+	mergeFrontMatterString(path.Root("description"), fm.Description, config.Description, &plan.Description, diags)
+	mergeFrontMatterString(path.Root("publish_start_date"), fm.PublishStartDate, config.PublishStartDate, &plan.PublishStartDate, diags)
+	mergeFrontMatterString(path.Root("publish_end_date"), fm.PublishEndDate, config.PublishEndDate, &plan.PublishEndDate, diags)
+	mergeFrontMatterString(path.Root("script_css"), fm.ScriptCss, config.ScriptCss, &plan.ScriptCss, diags)
+	mergeFrontMatterString(path.Root("script_js"), fm.ScriptJs, config.ScriptJs, &plan.ScriptJs, diags)
+
+	if fm.IsPrivate != nil {
+		switch {
+		case config.IsPrivate.IsNull():
+			plan.IsPrivate = types.BoolValue(*fm.IsPrivate)
+		case !config.IsPrivate.IsUnknown() && config.IsPrivate.ValueBool() != *fm.IsPrivate:
+			addFrontMatterConflict(path.Root("is_private"), diags)
+		}
+	}
+
+	if fm.Tags != nil {
+		tags, d := types.SetValueFrom(ctx, types.StringType, fm.Tags)
+		diags.Append(d...)
+		switch {
+		case config.Tags.IsNull():
+			plan.Tags = tags
+		case !config.Tags.IsUnknown() && !config.Tags.Equal(tags):
+			addFrontMatterConflict(path.Root("tags"), diags)
+		}
+	}
+}
+
+// fill sets the attributes of data that are still unknown, e.g. because the
+// content was not known during the plan.
+func (fm *frontMatter) fill(ctx context.Context, data *pageResourceModel, diags *diag.Diagnostics) {
+	for _, f := range []struct {
+		value *string
+		attr  *types.String
+	}{
+		{fm.Title, &data.Title},
+		{fm.Description, &data.Description},
+		{fm.PublishStartDate, &data.PublishStartDate},
+		{fm.PublishEndDate, &data.PublishEndDate},
+		{fm.ScriptCss, &data.ScriptCss},
+		{fm.ScriptJs, &data.ScriptJs},
+	} {
+		if f.value != nil && f.attr.IsUnknown() {
+			*f.attr = types.StringValue(*f.value)
+		}
+	}
+	if fm.IsPrivate != nil && data.IsPrivate.IsUnknown() {
+		data.IsPrivate = types.BoolValue(*fm.IsPrivate)
+	}
+	if fm.Tags != nil && data.Tags.IsUnknown() {
+		tags, d := types.SetValueFrom(ctx, types.StringType, fm.Tags)
+		diags.Append(d...)
+		data.Tags = tags
+	}
+}
+
+// unknownFrontMatter marks the attributes of plan that are not set in config
+// as unknown, because they may come from front matter in content that is not
+// known yet.
+func unknownFrontMatter(config *pageResourceModel, plan *pageResourceModel) {
+	for _, f := range []struct {
+		configured types.String
+		attr       *types.String
+	}{
+		{config.Title, &plan.Title},
+		{config.Description, &plan.Description},
+		{config.PublishStartDate, &plan.PublishStartDate},
+		{config.PublishEndDate, &plan.PublishEndDate},
+		{config.ScriptCss, &plan.ScriptCss},
+		{config.ScriptJs, &plan.ScriptJs},
+	} {
+		if f.configured.IsNull() {
+			*f.attr = types.StringUnknown()
+		}
+	}
+	if config.IsPrivate.IsNull() {
+		plan.IsPrivate = types.BoolUnknown()
+	}
+	if config.Tags.IsNull() {
+		plan.Tags = types.SetUnknown(types.StringType)
+	}
+}
+
+func mergeFrontMatterString(attribute path.Path, value *string, configured types.String, planned *types.String, diags *diag.Diagnostics) {
+	if value == nil {
+		return
+	}
+
+	switch {
+	case configured.IsNull():
+		*planned = types.StringValue(*value)
+	case !configured.IsUnknown() && configured.ValueString() != *value:
+		addFrontMatterConflict(attribute, diags)
+	}
+}
+
+func addFrontMatterConflict(attribute path.Path, diags *diag.Diagnostics) {
+	diags.AddAttributeWarning(
+		attribute,
+		"Attribute conflicts with front matter",
+		fmt.Sprintf("%s is set in the configuration and in the front matter of the content with different values. "+
+			"The configured value is used, remove one of them to resolve the conflict.", attribute),
+	)
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		title   string
+		body    string
+		err     bool
+	}{
+		{"no front matter", "# Hello", "", "# Hello", false},
+		{"front matter", "---\ntitle: Hello\n---\n# Hello", "Hello", "# Hello", false},
+		{"crlf", "---\r\ntitle: Hello\r\n---\r\n# Hello", "Hello", "# Hello", false},
+		{"empty body", "---\ntitle: Hello\n---", "Hello", "", false},
+		{"horizontal rule later", "# Hello\n---\ntitle: Hello\n---\n", "", "# Hello\n---\ntitle: Hello\n---\n", false},
+		{"not closed", "---\ntitle: Hello\n# Hello", "", "", true},
+		{"invalid yaml", "---\ntitle: [Hello\n---\n# Hello", "", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fm, body, err := splitFrontMatter(tc.content)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if body != tc.body {
+				t.Errorf("expected body %q, got %q", tc.body, body)
+			}
+			var title string
+			if fm != nil && fm.Title != nil {
+				title = *fm.Title
+			}
+			if title != tc.title {
+				t.Errorf("expected title %q, got %q", tc.title, title)
+			}
+		})
+	}
+}
+
+func TestFrontMatterMerge(t *testing.T) {
+	ctx := context.Background()
+	fm, _, err := splitFrontMatter("---\ntitle: From front matter\ndescription: Hello\ntags: [a, b]\nis_private: true\npublish_start_date: 2023-06-01T00:00:00Z\n---\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &pageResourceModel{
+		Title:            types.StringValue("From config"),
+		Description:      types.StringNull(),
+		Tags:             types.SetNull(types.StringType),
+		IsPrivate:        types.BoolNull(),
+		PublishStartDate: types.StringNull(),
+		PublishEndDate:   types.StringNull(),
+		ScriptCss:        types.StringNull(),
+		ScriptJs:         types.StringNull(),
+	}
+	plan := &pageResourceModel{
+		Title:            types.StringValue("From config"),
+		Description:      types.StringValue(""),
+		Tags:             types.SetUnknown(types.StringType),
+		IsPrivate:        types.BoolValue(false),
+		PublishStartDate: types.StringValue(""),
+		PublishEndDate:   types.StringValue(""),
+		ScriptCss:        types.StringUnknown(),
+		ScriptJs:         types.StringUnknown(),
+	}
+	var diags diag.Diagnostics
+	fm.merge(ctx, config, plan, &diags)
+
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected one conflict warning, got %v", diags)
+	}
+	if plan.Title.ValueString() != "From config" {
+		t.Errorf("expected configured title to win, got %s", plan.Title)
+	}
+	if plan.Description.ValueString() != "Hello" {
+		t.Errorf("expected description from front matter, got %s", plan.Description)
+	}
+	if !plan.IsPrivate.ValueBool() {
+		t.Error("expected is_private from front matter")
+	}
+	if plan.PublishStartDate.ValueString() != "2023-06-01T00:00:00Z" {
+		t.Errorf("expected publish_start_date from front matter, got %s", plan.PublishStartDate)
+	}
+	if len(plan.Tags.Elements()) != 2 {
+		t.Errorf("expected tags from front matter, got %s", plan.Tags)
+	}
+	if !plan.ScriptCss.IsUnknown() {
+		t.Errorf("expected script_css to stay unknown, got %s", plan.ScriptCss)
+	}
+}
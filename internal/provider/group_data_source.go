@@ -144,7 +144,7 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	wresp, err := wikijs.GetGroup(ctx, d.client.graphql, int(state.Id.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError("Get Group Query failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Group Query failed", err)
 		return
 	}
 
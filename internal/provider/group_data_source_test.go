@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccGroupDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_group" "test" {
+  group_id = 2
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_group.test", "name", "Guests"),
+					resource.TestCheckResourceAttr("data.wikijs_group.test", "is_system", "true"),
+					resource.TestCheckResourceAttr("data.wikijs_group.test", "permissions.#", "3"),
+					resource.TestCheckResourceAttr("data.wikijs_group.test", "page_rules.0.id", "guest"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.Group(2).RedirectOnLogin = "/public" })
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_group.test", "redirect_on_login", "/public"),
+			},
+		},
+	})
+}
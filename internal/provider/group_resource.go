@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,8 +20,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &groupResource{}
-	_ resource.ResourceWithConfigure = &groupResource{}
+	_ resource.Resource                = &groupResource{}
+	_ resource.ResourceWithConfigure   = &groupResource{}
+	_ resource.ResourceWithImportState = &groupResource{}
 )
 
 // NewGroupResource is a helper function to simplify the provider implementation.
@@ -195,12 +197,8 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	wresp, err := wikijs.CreateGroup(ctx, r.client.graphql, data.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Create Wiki.js Group Request failed", err.Error())
-		return
-	}
-	if !wresp.Groups.Create.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not create Wiki.js group: %s", wresp.Groups.Create.ResponseResult.Slug), wresp.Groups.Create.ResponseResult.Message)
+	if err := responseError(err, &wresp.Groups.Create.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not create Wiki.js group", err)
 		return
 	}
 
@@ -209,12 +207,8 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 
 	wresp2, err := wikijs.UpdateGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()), data.Name.ValueString(), data.RedirectOnLogin.ValueString(), permissions, pageRules)
-	if err != nil {
-		resp.Diagnostics.AddWarning("Finalize Wiki.js Group Request failed", err.Error())
-		return
-	}
-	if !wresp2.Groups.Update.ResponseResult.Succeeded {
-		resp.Diagnostics.AddWarning(fmt.Sprintf("Could not finalize Wiki.js group: %s", wresp2.Groups.Update.ResponseResult.Slug), wresp2.Groups.Update.ResponseResult.Message)
+	if err := responseError(err, &wresp2.Groups.Update.ResponseResult); err != nil {
+		resp.Diagnostics.AddWarning("Could not finalize Wiki.js group", err.Error())
 		return
 	}
 }
@@ -230,7 +224,7 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	wresp, err := wikijs.GetGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError("Get Wiki.JS Group Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Wiki.JS Group Request failed", err)
 		return
 	}
 
@@ -300,15 +294,17 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 			Path:    r.Path.ValueString(),
 			Locales: locales,
 		}
+
+		// Rules added to the end of the list have no id yet
+		if r.Id.IsUnknown() {
+			pageRules[i].Id = fmt.Sprintf("tf_%d_%d", data.Id.ValueInt64(), i)
+			data.PageRules[i].Id = types.StringValue(pageRules[i].Id)
+		}
 	}
 
 	wresp, err := wikijs.UpdateGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()), data.Name.ValueString(), data.RedirectOnLogin.ValueString(), permissions, pageRules)
-	if err != nil {
-		resp.Diagnostics.AddError("Update Wiki.js Group Request failed", err.Error())
-		return
-	}
-	if !wresp.Groups.Update.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update Wiki.js group: %s", wresp.Groups.Update.ResponseResult.Slug), wresp.Groups.Update.ResponseResult.Message)
+	if err := responseError(err, &wresp.Groups.Update.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update Wiki.js group", err)
 		return
 	}
 
@@ -325,12 +321,12 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	wresp, err := wikijs.DeleteGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("Delete Wiki.js Group Request failed", err.Error())
-		return
-	}
-	if !wresp.Groups.Delete.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not delete Wiki.js group: %s", wresp.Groups.Delete.ResponseResult.Slug), wresp.Groups.Delete.ResponseResult.Message)
+	if err := responseError(err, &wresp.Groups.Delete.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not delete Wiki.js group", err)
 		return
 	}
 }
+
+func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importIntId(ctx, path.Root("id"), req, resp)
+}
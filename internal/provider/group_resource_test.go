@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccGroupResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupResourceConfig(srv, "Editors", "read:pages"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("wikijs_group.test", "id"),
+					resource.TestCheckResourceAttr("wikijs_group.test", "name", "Editors"),
+					resource.TestCheckResourceAttr("wikijs_group.test", "is_system", "false"),
+					resource.TestCheckResourceAttr("wikijs_group.test", "redirect_on_login", "/"),
+					resource.TestCheckResourceAttr("wikijs_group.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("wikijs_group.test", "page_rules.0.path", "docs"),
+					resource.TestCheckResourceAttrSet("wikijs_group.test", "page_rules.0.id"),
+					// The permissions are only set when the group is finalized
+					testAccCheckGroup(srv, "Editors", func(g *wikijstest.Group) error {
+						if len(g.Permissions) != 1 || g.Permissions[0] != "read:pages" {
+							return fmt.Errorf("expected permissions [read:pages], got %v", g.Permissions)
+						}
+						if len(g.PageRules) != 1 || g.PageRules[0].Path != "docs" {
+							return fmt.Errorf("expected page rule for docs, got %v", g.PageRules)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      "wikijs_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGroupResourceConfig(srv, "Authors", "write:pages"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_group.test", "name", "Authors"),
+					resource.TestCheckResourceAttr("wikijs_group.test", "permissions.0", "write:pages"),
+					testAccCheckGroup(srv, "Authors", nil),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						for _, g := range store.Groups {
+							if g.Name == "Authors" {
+								g.Name = "Renamed"
+								g.Permissions = []string{"manage:system"}
+							}
+						}
+					})
+				},
+				Config: testAccGroupResourceConfig(srv, "Authors", "write:pages"),
+				Check: testAccCheckGroup(srv, "Authors", func(g *wikijstest.Group) error {
+					if len(g.Permissions) != 1 || g.Permissions[0] != "write:pages" {
+						return fmt.Errorf("expected permissions [write:pages], got %v", g.Permissions)
+					}
+					return nil
+				}),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			var count int
+			srv.Update(func(store *wikijstest.Store) { count = len(store.Groups) })
+			if count != 2 {
+				return fmt.Errorf("expected only the system groups to remain, got %d groups", count)
+			}
+			return nil
+		},
+	})
+}
+
+func testAccGroupResourceConfig(srv *wikijstest.Server, name, permission string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_group" "test" {
+  name        = %[1]q
+  permissions = [%[2]q]
+  page_rules = [{
+    deny    = false
+    match   = "START"
+    roles   = [%[2]q]
+    path    = "docs"
+    locales = ["en"]
+  }]
+}
+`, name, permission)
+}
+
+// testAccCheckGroup checks that a group with the given name exists and passes
+// check, if any.
+func testAccCheckGroup(srv *wikijstest.Server, name string, check func(*wikijstest.Group) error) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var group *wikijstest.Group
+		srv.Update(func(store *wikijstest.Store) {
+			for _, g := range store.Groups {
+				if g.Name == name {
+					copied := *g
+					group = &copied
+				}
+			}
+		})
+		if group == nil {
+			return fmt.Errorf("group %q does not exist", name)
+		}
+		if check != nil {
+			return check(group)
+		}
+		return nil
+	}
+}
@@ -123,7 +123,7 @@ func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	wresp, err := wikijs.GetGroups(ctx, d.client.graphql, state.Filter.ValueString(), state.OrderBy.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Get Group List Query failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Group List Query failed", err)
 		return
 	}
 
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccGroupsDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_groups" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_groups.test", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_groups.test", "groups.0.name", "Administrators"),
+					resource.TestCheckResourceAttr("data.wikijs_groups.test", "groups.0.user_count", "1"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.Groups = append(store.Groups, &wikijstest.Group{Id: 100, Name: "Editors", Permissions: []string{}, PageRules: []wikijstest.PageRule{}})
+					})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_groups.test", "groups.#", "3"),
+					resource.TestCheckResourceAttr("data.wikijs_groups.test", "groups.2.id", "100"),
+					resource.TestCheckResourceAttr("data.wikijs_groups.test", "groups.2.is_system", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// importSingleton imports resources that exist exactly once per wiki.js, e.g.
+// the site config. The import id is ignored and Read fills in all attributes.
+func importSingleton(ctx context.Context, resp *resource.ImportStateResponse) {
+	typ := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, t := range typ.AttributeTypes {
+		attributes[name] = tftypes.NewValue(t, nil)
+	}
+	resp.State.Raw = tftypes.NewValue(typ, attributes)
+}
+
+// importIntId imports resources by their numeric wiki.js id into attribute.
+func importIntId(ctx context.Context, attribute path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if id, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddAttributeError(attribute, "Could not parse id", err.Error())
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attribute, int64(id))...)
+	}
+}
@@ -15,8 +15,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &localizationResource{}
-	_ resource.ResourceWithConfigure = &localizationResource{}
+	_ resource.Resource                = &localizationResource{}
+	_ resource.ResourceWithConfigure   = &localizationResource{}
+	_ resource.ResourceWithImportState = &localizationResource{}
 )
 
 // NewLocalizationResource is a helper function to simplify the provider implementation.
@@ -108,12 +109,8 @@ func (r *localizationResource) Create(ctx context.Context, req resource.CreateRe
 	install = append(install, data.Locale.ValueString())
 	for _, l := range install {
 		wresp, err := wikijs.DownloadLocale(ctx, r.client.graphql, l)
-		if err != nil {
-			resp.Diagnostics.AddError("Download Locale Request failed", err.Error())
-			continue
-		}
-		if !wresp.Localization.DownloadLocale.ResponseResult.Succeeded {
-			resp.Diagnostics.AddError(fmt.Sprintf("Could not install locale '%s': %s", l, wresp.Localization.DownloadLocale.ResponseResult.Slug), wresp.Localization.DownloadLocale.ResponseResult.Message)
+		if err := responseError(err, &wresp.Localization.DownloadLocale.ResponseResult); err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, fmt.Sprintf("Could not install locale '%s'", l), err)
 		}
 	}
 	if resp.Diagnostics.HasError() {
@@ -121,12 +118,8 @@ func (r *localizationResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	wresp, err := wikijs.SetLocalization(ctx, r.client.graphql, data.Locale.ValueString(), data.AutoUpdate.ValueBool(), data.Namespacing.ValueBool(), namespaces)
-	if err != nil {
-		resp.Diagnostics.AddError("Update Localization Request failed", err.Error())
-		return
-	}
-	if !wresp.Localization.UpdateLocale.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update localization: %s", wresp.Localization.UpdateLocale.ResponseResult.Slug), wresp.Localization.UpdateLocale.ResponseResult.Message)
+	if err := responseError(err, &wresp.Localization.UpdateLocale.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update localization", err)
 		return
 	}
 
@@ -144,7 +137,7 @@ func (r *localizationResource) Read(ctx context.Context, req resource.ReadReques
 
 	wresp, err := wikijs.GetLocalization(ctx, r.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Getting localization request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Getting localization request failed", err)
 		return
 	}
 
@@ -174,12 +167,8 @@ func (r *localizationResource) Update(ctx context.Context, req resource.UpdateRe
 	install = append(install, data.Locale.ValueString())
 	for _, l := range install {
 		wresp, err := wikijs.DownloadLocale(ctx, r.client.graphql, l)
-		if err != nil {
-			resp.Diagnostics.AddError("Download Locale Request failed", err.Error())
-			continue
-		}
-		if !wresp.Localization.DownloadLocale.ResponseResult.Succeeded {
-			resp.Diagnostics.AddError(fmt.Sprintf("Could not install locale '%s': %s", l, wresp.Localization.DownloadLocale.ResponseResult.Slug), wresp.Localization.DownloadLocale.ResponseResult.Message)
+		if err := responseError(err, &wresp.Localization.DownloadLocale.ResponseResult); err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, fmt.Sprintf("Could not install locale '%s'", l), err)
 		}
 	}
 	if resp.Diagnostics.HasError() {
@@ -187,12 +176,8 @@ func (r *localizationResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	wresp, err := wikijs.SetLocalization(ctx, r.client.graphql, data.Locale.ValueString(), data.AutoUpdate.ValueBool(), data.Namespacing.ValueBool(), namespaces)
-	if err != nil {
-		resp.Diagnostics.AddError("Update Localization Request failed", err.Error())
-		return
-	}
-	if !wresp.Localization.UpdateLocale.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update localization: %s", wresp.Localization.UpdateLocale.ResponseResult.Slug), wresp.Localization.UpdateLocale.ResponseResult.Message)
+	if err := responseError(err, &wresp.Localization.UpdateLocale.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update localization", err)
 		return
 	}
 
@@ -203,3 +188,7 @@ func (r *localizationResource) Update(ctx context.Context, req resource.UpdateRe
 func (r *localizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	resp.Diagnostics.AddWarning("Localization has no factory default", "Deleting the wikijs_localization resource just removes the resource from the terraform state. The settings in wiki.js are not changed.")
 }
+
+func (r *localizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
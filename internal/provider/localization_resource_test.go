@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccLocalizationResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLocalizationResourceConfig(srv, "de"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_localization.test", "locale", "de"),
+					resource.TestCheckResourceAttr("wikijs_localization.test", "namespacing", "true"),
+					resource.TestCheckResourceAttr("wikijs_localization.test", "namespaces.#", "2"),
+					testAccCheckLocalization(srv, "de"),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_localization.test",
+				ImportState:                          true,
+				ImportStateId:                        "localization",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "locale",
+			},
+			{
+				Config: testAccLocalizationResourceConfig(srv, "fr"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_localization.test", "locale", "fr"),
+					testAccCheckLocalization(srv, "fr"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.Localization["locale"] = "en" })
+				},
+				Config: testAccLocalizationResourceConfig(srv, "fr"),
+				Check:  testAccCheckLocalization(srv, "fr"),
+			},
+		},
+		// The localization is left alone on destroy
+		CheckDestroy: testAccCheckLocalization(srv, "fr"),
+	})
+}
+
+func testAccLocalizationResourceConfig(srv *wikijstest.Server, locale string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_localization" "test" {
+  locale      = %[1]q
+  namespacing = true
+  namespaces  = ["en", %[1]q]
+}
+`, locale)
+}
+
+// testAccCheckLocalization checks the base locale and that it was installed.
+func testAccCheckLocalization(srv *wikijstest.Server, locale string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var actual interface{}
+		var isInstalled bool
+		srv.Update(func(store *wikijstest.Store) {
+			actual = store.Localization["locale"]
+			for _, l := range store.Locales {
+				if l.Code == locale {
+					isInstalled = l.IsInstalled
+				}
+			}
+		})
+		if actual != locale {
+			return fmt.Errorf("expected locale %s, got %v", locale, actual)
+		}
+		if !isInstalled {
+			return fmt.Errorf("expected locale %s to be installed", locale)
+		}
+		return nil
+	}
+}
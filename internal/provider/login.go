@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+)
+
+// loginCredentials holds everything needed to log in to wiki.js.
+type loginCredentials struct {
+	email      string
+	password   string
+	strategy   string
+	totpSecret string
+}
+
+// loginResult is the subset of the login and loginTFA responses we need to
+// decide how to continue.
+type loginResult interface {
+	GetJwt() string
+	GetMustChangePwd() bool
+	GetMustProvideTFA() bool
+	GetMustSetupTFA() bool
+	GetContinuationToken() string
+}
+
+// login authenticates with the given credentials and returns the session JWT.
+// Accounts with two-factor authentication are logged in through loginTFA when
+// a TOTP secret is configured.
+func login(ctx context.Context, client graphql.Client, creds loginCredentials) (string, error) {
+	wresp, err := wikijs.Login(ctx, client, creds.email, creds.password, creds.strategy)
+	if err := responseError(err, &wresp.Authentication.Login.ResponseResult); err != nil {
+		return "", err
+	}
+	if err := checkLoginResult(&wresp.Authentication.Login); err != nil {
+		return "", err
+	}
+
+	if !wresp.Authentication.Login.MustProvideTFA {
+		return wresp.Authentication.Login.Jwt, nil
+	}
+
+	if creds.totpSecret == "" {
+		return "", errors.New("the account requires a two-factor authentication code, configure totp_secret to log in")
+	}
+	code, err := totpCode(creds.totpSecret, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	tfaResp, err := wikijs.LoginTFA(ctx, client, wresp.Authentication.Login.ContinuationToken, code)
+	if err := responseError(err, &tfaResp.Authentication.LoginTFA.ResponseResult); err != nil {
+		return "", fmt.Errorf("two-factor login failed: %w", err)
+	}
+	if err := checkLoginResult(&tfaResp.Authentication.LoginTFA); err != nil {
+		return "", err
+	}
+	if tfaResp.Authentication.LoginTFA.Jwt == "" {
+		return "", errors.New("wiki.js did not accept the two-factor authentication code")
+	}
+
+	return tfaResp.Authentication.LoginTFA.Jwt, nil
+}
+
+// checkLoginResult turns the interactive steps wiki.js may ask for into errors.
+func checkLoginResult(result loginResult) error {
+	switch {
+	case result.GetMustChangePwd():
+		return errors.New("wiki.js requires a password change for this account. Log in through the web interface once, set a new password and update the provider configuration")
+	case result.GetMustSetupTFA():
+		return errors.New("wiki.js requires two-factor authentication to be set up for this account. Log in through the web interface once, set up an authenticator app and configure its secret as totp_secret")
+	case result.GetJwt() == "" && !result.GetMustProvideTFA():
+		return errors.New("wiki.js did not return a session token")
+	}
+
+	return nil
+}
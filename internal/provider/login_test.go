@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+// testTOTPSecret is the RFC 6238 test secret "12345678901234567890".
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestLoginTFA(t *testing.T) {
+	srv := testAccServer(t, wikijstest.WithAdminTOTPSecret(testTOTPSecret))
+	client := graphql.NewClient(srv.URL+"/graphql", http.DefaultClient)
+	creds := loginCredentials{
+		email:      wikijstest.AdminEmail,
+		password:   wikijstest.AdminPassword,
+		strategy:   "local",
+		totpSecret: testTOTPSecret,
+	}
+
+	jwt, err := login(context.Background(), client, creds)
+	if err != nil {
+		t.Fatalf("expected two-factor login to succeed, got: %s", err)
+	}
+	if jwt == "" {
+		t.Errorf("expected a session token")
+	}
+
+	creds.totpSecret = ""
+	if _, err := login(context.Background(), client, creds); err == nil || !strings.Contains(err.Error(), "totp_secret") {
+		t.Errorf("expected login without secret to ask for totp_secret, got: %v", err)
+	}
+
+	creds.totpSecret = "JBSWY3DPEHPK3PXP"
+	if _, err := login(context.Background(), client, creds); err == nil || !strings.Contains(err.Error(), "two-factor login failed") {
+		t.Errorf("expected login with wrong secret to fail, got: %v", err)
+	}
+}
+
+func TestCheckLoginResult(t *testing.T) {
+	for name, result := range map[string]*wikijs.LoginAuthenticationAuthenticationMutationLoginAuthenticationLoginResponse{
+		"password change": {MustChangePwd: true},
+		"2FA setup":       {MustSetupTFA: true},
+		"no token":        {},
+	} {
+		if err := checkLoginResult(result); err == nil {
+			t.Errorf("expected %s to fail the login", name)
+		}
+	}
+
+	for name, result := range map[string]*wikijs.LoginAuthenticationAuthenticationMutationLoginAuthenticationLoginResponse{
+		"token":        {Jwt: "jwt"},
+		"2FA required": {MustProvideTFA: true, ContinuationToken: "token"},
+	} {
+		if err := checkLoginResult(result); err != nil {
+			t.Errorf("expected %s to continue the login, got: %s", name, err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,8 +18,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &managedSystemGroupResource{}
-	_ resource.ResourceWithConfigure = &managedSystemGroupResource{}
+	_ resource.Resource                = &managedSystemGroupResource{}
+	_ resource.ResourceWithConfigure   = &managedSystemGroupResource{}
+	_ resource.ResourceWithImportState = &managedSystemGroupResource{}
 )
 
 // NewManagedSystemGroupResource is a helper function to simplify the provider implementation.
@@ -53,6 +55,9 @@ func (r *managedSystemGroupResource) Schema(_ context.Context, _ resource.Schema
 			},
 			"name": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"redirect_on_login": schema.StringAttribute{
 				Computed: true,
@@ -153,6 +158,8 @@ func (r *managedSystemGroupResource) Create(ctx context.Context, req resource.Cr
 			Path:    r.Path.ValueString(),
 			Locales: locales,
 		}
+
+		data.PageRules[i].Id = types.StringValue(pageRules[i].Id)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -161,7 +168,7 @@ func (r *managedSystemGroupResource) Create(ctx context.Context, req resource.Cr
 
 	wresp, err := wikijs.GetGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError("Get Wiki.JS Manged Group Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Wiki.JS Manged Group Request failed", err)
 		return
 	}
 
@@ -183,12 +190,8 @@ func (r *managedSystemGroupResource) Create(ctx context.Context, req resource.Cr
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 
 	wresp2, err := wikijs.UpdateGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()), data.Name.ValueString(), data.RedirectOnLogin.ValueString(), permissions, pageRules)
-	if err != nil {
-		resp.Diagnostics.AddError("Update Wiki.js system Group Request failed", err.Error())
-		return
-	}
-	if !wresp2.Groups.Update.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update Wiki.js system group: %s", wresp2.Groups.Update.ResponseResult.Slug), wresp2.Groups.Update.ResponseResult.Message)
+	if err := responseError(err, &wresp2.Groups.Update.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update Wiki.js system group", err)
 		return
 	}
 }
@@ -204,7 +207,7 @@ func (r *managedSystemGroupResource) Read(ctx context.Context, req resource.Read
 
 	wresp, err := wikijs.GetGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError("Get Wiki.JS system Group Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Wiki.JS system Group Request failed", err)
 		return
 	}
 
@@ -273,15 +276,17 @@ func (r *managedSystemGroupResource) Update(ctx context.Context, req resource.Up
 			Path:    r.Path.ValueString(),
 			Locales: locales,
 		}
+
+		// Rules added to the end of the list have no id yet
+		if r.Id.IsUnknown() {
+			pageRules[i].Id = fmt.Sprintf("tf_%d_%d", data.Id.ValueInt64(), i)
+			data.PageRules[i].Id = types.StringValue(pageRules[i].Id)
+		}
 	}
 
 	wresp, err := wikijs.UpdateGroup(ctx, r.client.graphql, int(data.Id.ValueInt64()), data.Name.ValueString(), data.RedirectOnLogin.ValueString(), permissions, pageRules)
-	if err != nil {
-		resp.Diagnostics.AddError("Update Wiki.js system Group Request failed", err.Error())
-		return
-	}
-	if !wresp.Groups.Update.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update Wiki.js system group: %s", wresp.Groups.Update.ResponseResult.Slug), wresp.Groups.Update.ResponseResult.Message)
+	if err := responseError(err, &wresp.Groups.Update.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update Wiki.js system group", err)
 		return
 	}
 
@@ -292,3 +297,7 @@ func (r *managedSystemGroupResource) Update(ctx context.Context, req resource.Up
 func (r *managedSystemGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	resp.Diagnostics.AddWarning("Can not delete system group", "Deleting the wikijs_managed_system_group resource just removes the resource from the terraform state. The system group in wiki.js is not changed.")
 }
+
+func (r *managedSystemGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importIntId(ctx, path.Root("group_id"), req, resp)
+}
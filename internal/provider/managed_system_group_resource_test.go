@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccManagedSystemGroupResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccManagedSystemGroupResourceConfig(srv, "docs"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_managed_system_group.guests", "name", "Guests"),
+					resource.TestCheckResourceAttr("wikijs_managed_system_group.guests", "page_rules.0.path", "docs"),
+					resource.TestCheckResourceAttrSet("wikijs_managed_system_group.guests", "page_rules.0.id"),
+					testAccCheckGroupRulePath(srv, "docs"),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_managed_system_group.guests",
+				ImportState:                          true,
+				ImportStateId:                        "2",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "group_id",
+			},
+			{
+				Config: testAccManagedSystemGroupResourceConfig(srv, "public"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_managed_system_group.guests", "page_rules.0.path", "public"),
+					testAccCheckGroupRulePath(srv, "public"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.Group(2).PageRules[0].Path = ""
+					})
+				},
+				Config: testAccManagedSystemGroupResourceConfig(srv, "public"),
+				Check:  testAccCheckGroupRulePath(srv, "public"),
+			},
+		},
+		// System groups are left alone on destroy
+		CheckDestroy: testAccCheckGroupRulePath(srv, "public"),
+	})
+}
+
+func testAccManagedSystemGroupResourceConfig(srv *wikijstest.Server, path string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_managed_system_group" "guests" {
+  group_id    = 2
+  permissions = ["read:pages"]
+  page_rules = [{
+    deny    = false
+    match   = "START"
+    roles   = ["read:pages"]
+    path    = %q
+    locales = []
+  }]
+}
+`, path)
+}
+
+func testAccCheckGroupRulePath(srv *wikijstest.Server, path string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var rules []wikijstest.PageRule
+		srv.Update(func(store *wikijstest.Store) {
+			rules = append(rules, store.Group(2).PageRules...)
+		})
+		if len(rules) != 1 || rules[0].Path != path {
+			return fmt.Errorf("expected a single page rule for %q, got %v", path, rules)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// normalizeContent removes the differences wiki.js introduces when it saves
+// a page with the given editor, so they are not reported as drift.
+func normalizeContent(editor string, content string) string {
+	content = normalizeText(content)
+	if editor == "ckeditor" {
+		return normalizeHTML(content)
+	}
+
+	return content
+}
+
+// normalizeText unifies line endings and drops trailing whitespace at the
+// end of the text.
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	return strings.TrimRight(text, " \t\n")
+}
+
+// normalizeHTML serializes HTML the same way no matter how it was written,
+// like ckeditor does on save. Quotes, void elements and entities are
+// rendered canonically and whitespace around block-level elements is
+// dropped. Text that is no valid HTML fragment is returned unchanged.
+func normalizeHTML(text string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(text), body)
+	if err != nil {
+		return text
+	}
+	for _, n := range nodes {
+		body.AppendChild(n)
+	}
+	trimBlankText(body)
+
+	var b strings.Builder
+	for n := body.FirstChild; n != nil; n = n.NextSibling {
+		if err := html.Render(&b, n); err != nil {
+			return text
+		}
+	}
+
+	return b.String()
+}
+
+// blockElements lists the elements browsers do not render whitespace next
+// to, so it only formats the source.
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true,
+	atom.Body: true, atom.Caption: true, atom.Dd: true, atom.Details: true,
+	atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Fieldset: true,
+	atom.Figcaption: true, atom.Figure: true, atom.Footer: true, atom.Form: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true,
+	atom.Nav: true, atom.Ol: true, atom.P: true, atom.Pre: true,
+	atom.Section: true, atom.Summary: true, atom.Table: true, atom.Tbody: true,
+	atom.Td: true, atom.Tfoot: true, atom.Th: true, atom.Thead: true,
+	atom.Tr: true, atom.Ul: true,
+}
+
+// trimBlankText removes whitespace-only text next to block-level elements
+// below n. Whitespace between inline elements separates words and is kept,
+// as is any whitespace in elements where it is significant.
+func trimBlankText(n *html.Node) {
+	if n.DataAtom == atom.Pre || n.DataAtom == atom.Textarea {
+		return
+	}
+
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if isBlank(c) && nextToBlock(c) {
+			n.RemoveChild(c)
+		} else {
+			trimBlankText(c)
+		}
+		c = next
+	}
+}
+
+// nextToBlock reports whether a sibling of n, or its parent if n is the
+// first or last child, is a block-level element.
+func nextToBlock(n *html.Node) bool {
+	if n.PrevSibling == nil || n.NextSibling == nil {
+		if isBlock(n.Parent) {
+			return true
+		}
+	}
+
+	return isBlock(n.PrevSibling) || isBlock(n.NextSibling)
+}
+
+func isBlock(n *html.Node) bool {
+	return n != nil && n.Type == html.ElementNode && blockElements[n.DataAtom]
+}
+
+func isBlank(n *html.Node) bool {
+	return n.Type == html.TextNode && strings.TrimSpace(n.Data) == ""
+}
@@ -0,0 +1,33 @@
+package provider
+
+import "testing"
+
+func TestNormalizeContent(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		editor string
+		a, b   string
+		equal  bool
+	}{
+		{"line endings", "markdown", "# Hello\r\n\r\nWorld", "# Hello\n\nWorld", true},
+		{"trailing newlines", "markdown", "# Hello", "# Hello\n\n", true},
+		{"changed text", "markdown", "# Hello", "# Hello World", false},
+		{"leading whitespace", "markdown", "    code", "code", false},
+		{"html quoting", "ckeditor", `<p class='lead'>Hello</p>`, `<p class="lead">Hello</p>`, true},
+		{"html void elements", "ckeditor", `<p>Hello<br/>World</p>`, `<p>Hello<br>World</p>`, true},
+		{"html whitespace between tags", "ckeditor", "<ul>\n  <li>a</li>\n  <li>b</li>\n</ul>", "<ul><li>a</li><li>b</li></ul>", true},
+		{"html whitespace between inline elements", "ckeditor", `<p><strong>Hello</strong> <em>World</em></p>`, `<p><strong>Hello</strong><em>World</em></p>`, false},
+		{"html whitespace around blocks", "ckeditor", "<p>Hello</p>\n<p><strong>a</strong> <em>b</em></p>", "<p>Hello</p><p><strong>a</strong> <em>b</em></p>", true},
+		{"html entities", "ckeditor", `<p>&#39;a&#39; &amp; b</p>`, `<p>'a' &amp; b</p>`, true},
+		{"html pre whitespace", "ckeditor", "<pre>a\n  b</pre>", "<pre>a\nb</pre>", false},
+		{"html changed text", "ckeditor", `<p>Hello</p>`, `<p>World</p>`, false},
+		{"html only for ckeditor", "code", `<p class='lead'>Hello</p>`, `<p class="lead">Hello</p>`, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := normalizeContent(tc.editor, tc.a), normalizeContent(tc.editor, tc.b)
+			if (a == b) != tc.equal {
+				t.Errorf("expected equal=%t, got %q and %q", tc.equal, a, b)
+			}
+		})
+	}
+}
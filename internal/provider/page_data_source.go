@@ -91,7 +91,7 @@ func (d *pageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Optional:    true,
 				Description: "Path of the page (omit leading slash)",
 				Validators: []validator.String{
-					stringvalidator.AtLeastOneOf(path.MatchRoot("id")),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("page_id")),
 					stringvalidator.AlsoRequires(path.MatchRoot("locale")),
 				},
 			},
@@ -280,7 +280,7 @@ func (d *pageDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	if !state.Id.IsNull() {
 		wresp, err := wikijs.GetPage(ctx, d.client.graphql, int(state.Id.ValueInt64()))
 		if err != nil {
-			resp.Diagnostics.AddError("Get Page Query failed", err.Error())
+			addErrorDiagnostic(&resp.Diagnostics, "Get Page Query failed", err)
 			return
 		}
 		page = &wresp.Pages.Single
@@ -296,7 +296,7 @@ func (d *pageDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	} else {
 		wresp, err := wikijs.GetPageByPath(ctx, d.client.graphql, state.Path.ValueString(), state.Locale.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Get Page by Path Query failed", err.Error())
+			addErrorDiagnostic(&resp.Diagnostics, "Get Page by Path Query failed", err)
 			return
 		}
 		page = &wresp.Pages.SingleByPath
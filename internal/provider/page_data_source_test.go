@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccPageDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccPageResourceConfig(srv, "docs/hello", "Hello", "# Hello") + `
+data "wikijs_page" "by_id" {
+  page_id = wikijs_page.test.id
+}
+
+data "wikijs_page" "by_path" {
+  path   = wikijs_page.test.path
+  locale = wikijs_page.test.locale
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.wikijs_page.by_id", "page_id", "wikijs_page.test", "id"),
+					resource.TestCheckResourceAttr("data.wikijs_page.by_id", "content", "# Hello"),
+					resource.TestCheckResourceAttr("data.wikijs_page.by_id", "tags.#", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_page.by_id", "author_email", wikijstest.AdminEmail),
+					resource.TestCheckResourceAttrPair("data.wikijs_page.by_path", "page_id", "wikijs_page.test", "id"),
+					resource.TestCheckResourceAttr("data.wikijs_page.by_path", "title", "Hello"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.PageByPath("docs/hello", "en").AuthorName = "Someone else" })
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_page.by_id", "author_name", "Someone else"),
+					resource.TestCheckResourceAttr("data.wikijs_page.by_path", "author_name", "Someone else"),
+				),
+			},
+		},
+	})
+}
@@ -2,9 +2,14 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"strconv"
+	"os"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -13,9 +18,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
-	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -23,6 +28,7 @@ var (
 	_ resource.Resource                = &pageResource{}
 	_ resource.ResourceWithConfigure   = &pageResource{}
 	_ resource.ResourceWithImportState = &pageResource{}
+	_ resource.ResourceWithModifyPlan  = &pageResource{}
 )
 
 // NewPageResource is a helper function to simplify the provider implementation.
@@ -48,7 +54,13 @@ type pageResourceModel struct {
 	PublishEndDate   types.String `tfsdk:"publish_end_date"`
 	Tags             types.Set    `tfsdk:"tags"`
 	Content          types.String `tfsdk:"content"`
+	ContentFile      types.String `tfsdk:"content_file"`
+	ContentSha256    types.String `tfsdk:"content_sha256"`
+	Normalization    types.Bool   `tfsdk:"content_normalization"`
 	CreatedAt        types.String `tfsdk:"created_at"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+	ForceOverwrite   types.Bool   `tfsdk:"force_overwrite"`
+	AdoptExisting    types.Bool   `tfsdk:"adopt_existing"`
 	Editor           types.String `tfsdk:"editor"`
 	Locale           types.String `tfsdk:"locale"`
 	ScriptCss        types.String `tfsdk:"script_css"`
@@ -77,11 +89,7 @@ func (r *pageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"path": schema.StringAttribute{
 				Required:    true,
-				Description: "Path of the page (omit leading slash)",
-				PlanModifiers: []planmodifier.String{
-					// @Todo: Implement move
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "Path of the page (omit leading slash). Changing it moves the page and keeps its history.",
 			},
 			"hash": schema.StringAttribute{
 				Computed:    true,
@@ -91,12 +99,15 @@ func (r *pageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"title": schema.StringAttribute{
-				Required:    true,
-				Description: "Page Title",
+				Optional:    true,
+				Computed:    true,
+				Description: "Page Title. Required here or in the front matter of the content.",
 			},
 			"description": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "Meta description of the page for search engines",
+				Default:     stringdefault.StaticString(""),
 			},
 			"is_private": schema.BoolAttribute{
 				Optional:    true,
@@ -133,29 +144,68 @@ func (r *pageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "List of page tags",
 			},
 			"content": schema.StringAttribute{
-				Required:    true,
-				Description: "Content of the page (format is defined by editor)",
+				Optional: true,
+				Description: "Content of the page (format is defined by editor). Exactly one of content and content_file is required. " +
+					"A YAML front matter block between `---` lines at the top can set title, description, tags, is_private, " +
+					"publish_start_date, publish_end_date, script_css and script_js. It is removed before the content is saved, " +
+					"attributes set in the configuration take precedence.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content_file")),
+				},
+			},
+			"content_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path of a local file with the content of the page, e.g. a Markdown file kept in git. " +
+					"The content is not stored in the state, changes are detected and shown in plans through content_sha256.",
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex encoded SHA-256 hash of the content of the page, after content_normalization",
+			},
+			"content_normalization": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Ignore differences wiki.js introduces when it saves a page, so they are not reported as drift: " +
+					"line endings and trailing newlines of content, script_css and script_js, and the serialization of HTML written with ckeditor. " +
+					"Set to false to compare the content exactly.",
+				Default: booldefault.StaticBool(true),
 			},
 			"created_at": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
-				Description: "Creation date of this page (expect RFC 3399 timestamp). Use data source to get updated_at",
+				Description: "Creation date of this page (expect RFC 3399 timestamp)",
 			},
-			"editor": schema.StringAttribute{
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Date of the last change of this page (expect RFC 3399 timestamp). Updates fail if the page was changed in wiki.js after this date, see force_overwrite.",
+			},
+			"force_overwrite": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Overwrite changes made in wiki.js between plan and apply. By default the update fails and shows the latest version of the page.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("markdown"),
-				Description: "Editor type to use for this page",
+				Description: "Take over a page that already exists at path and locale instead of failing to create it. The existing page is updated with the configured values.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"editor": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("markdown"),
+				Description: "Editor type to use for this page, one of `markdown`, `ckeditor` (visual editor), `code` (raw HTML) or `asciidoc`. " +
+					"Changing only the editor converts the content of the page in wiki.js.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("markdown", "ckeditor", "code", "asciidoc"),
+				},
 			},
 			"locale": schema.StringAttribute{
 				Required:    true,
-				Description: "Language of this page",
-				PlanModifiers: []planmodifier.String{
-					// @Todo: Implement move
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "Language of this page. Changing it moves the page and keeps its history.",
 			},
 			"script_css": schema.StringAttribute{
 				Optional:    true,
@@ -217,6 +267,66 @@ func (d *pageResource) Configure(_ context.Context, req resource.ConfigureReques
 	d.client = client
 }
 
+// ModifyPlan fills attributes from the front matter of the content, computes
+// the hash of the planned content and marks the page hash as unknown when the
+// page is moved, wiki.js computes it from the path and locale.
+func (r *pageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config *pageResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Plans compare the hash, so content kept in files never shows up in
+	// them. The hash and the attributes front matter may set stay unknown
+	// while the content depends on other resources.
+	if !plan.Content.IsUnknown() && !plan.ContentFile.IsUnknown() {
+		content, err := pageContent(plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("content_file"), "Could not read content file", err.Error())
+			return
+		}
+		fm, body, err := splitFrontMatter(content)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(contentAttribute(plan), "Could not parse front matter", err.Error())
+			return
+		}
+		if fm != nil {
+			fm.merge(ctx, config, plan, &resp.Diagnostics)
+		}
+		if config.Title.IsNull() && (fm == nil || fm.Title == nil) {
+			resp.Diagnostics.AddAttributeError(path.Root("title"), "Missing page title", "Set title in the configuration or in the front matter of the content.")
+			return
+		}
+
+		plan.ContentSha256 = types.StringUnknown()
+		if !plan.Editor.IsUnknown() && !plan.Normalization.IsUnknown() {
+			plan.ContentSha256 = types.StringValue(plan.contentSha256(plan.Editor.ValueString(), body))
+		}
+	} else {
+		unknownFrontMatter(config, plan)
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state *pageResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !plan.Path.Equal(state.Path) || !plan.Locale.Equal(state.Locale) {
+			plan.Hash = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *pageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Read Terraform plan data into the model
@@ -226,6 +336,11 @@ func (r *pageResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	content, ok := plannedContent(ctx, data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
 	var tags []string
 	if data.Tags.IsNull() || data.Tags.IsUnknown() {
 		tags = []string{}
@@ -236,8 +351,33 @@ func (r *pageResource) Create(ctx context.Context, req resource.CreateRequest, r
 		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
 	}
 
+	// Pages built by hand before the wiki was managed by Terraform are updated
+	// instead of failing with a duplicate path
+	if data.AdoptExisting.ValueBool() {
+		existing, err := wikijs.GetPageByPath(ctx, r.client.graphql, data.Path.ValueString(), data.Locale.ValueString())
+		if err != nil && !isNotFound(err) {
+			addErrorDiagnostic(&resp.Diagnostics, "Could not look up existing page", err)
+			return
+		}
+		if err == nil {
+			data.Id = types.Int64Value(int64(existing.Pages.SingleByPath.Id))
+			if err := r.update(ctx, data, content, tags); err != nil {
+				addErrorDiagnostic(&resp.Diagnostics, "Could not adopt existing page", err)
+				return
+			}
+			resp.Diagnostics.AddWarning(
+				"Adopted existing page",
+				fmt.Sprintf("Page %d already existed at %s/%s and was updated with the configured values instead of being created.",
+					existing.Pages.SingleByPath.Id, data.Locale.ValueString(), data.Path.ValueString()),
+			)
+
+			resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+			return
+		}
+	}
+
 	wresp, err := wikijs.CreatePage(ctx, r.client.graphql,
-		data.Content.ValueString(),
+		content,
 		data.Description.ValueString(),
 		data.Editor.ValueString(),
 		data.IsPublished.ValueBool(),
@@ -251,12 +391,8 @@ func (r *pageResource) Create(ctx context.Context, req resource.CreateRequest, r
 		tags,
 		data.Title.ValueString(),
 	)
-	if err != nil {
-		resp.Diagnostics.AddError("Create Page Request failed", err.Error())
-		return
-	}
-	if !wresp.Pages.Create.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not create page: %s", wresp.Pages.Create.ResponseResult.Slug), wresp.Pages.Create.ResponseResult.Message)
+	if err := responseError(err, &wresp.Pages.Create.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not create page", err)
 		return
 	}
 
@@ -270,8 +406,13 @@ func (r *pageResource) Create(ctx context.Context, req resource.CreateRequest, r
 		data.PublishEndDate = types.StringValue(wresp.Pages.Create.Page.PublishEndDate)
 	}
 	data.CreatedAt = types.StringValue(wresp.Pages.Create.Page.CreatedAt)
-	data.ScriptCss = types.StringValue(wresp.Pages.Create.Page.ScriptCss)
-	data.ScriptJs = types.StringValue(wresp.Pages.Create.Page.ScriptJs)
+	data.UpdatedAt = types.StringValue(wresp.Pages.Create.Page.UpdatedAt)
+	if data.ScriptCss.IsUnknown() {
+		data.ScriptCss = types.StringValue(wresp.Pages.Create.Page.ScriptCss)
+	}
+	if data.ScriptJs.IsUnknown() {
+		data.ScriptJs = types.StringValue(wresp.Pages.Create.Page.ScriptJs)
+	}
 	data.CreatorId = types.Int64Value(int64(wresp.Pages.Create.Page.CreatorId))
 	data.CreatorName = types.StringValue(wresp.Pages.Create.Page.CreatorName)
 	data.CreatorEmail = types.StringValue(wresp.Pages.Create.Page.CreatorEmail)
@@ -290,15 +431,11 @@ func (r *pageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	wresp, err := wikijs.GetPage(ctx, r.client.graphql, int(data.Id.ValueInt64()))
 	if err != nil {
-		if list, ok := err.(gqlerror.List); ok {
-			for _, e := range list {
-				if e.Message == "This page does not exist." {
-					resp.State.RemoveResource(ctx)
-					return
-				}
-			}
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
 		}
-		resp.Diagnostics.AddError("Read Page Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Read Page Request failed", err)
 		return
 	}
 
@@ -329,12 +466,33 @@ func (r *pageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		data.Tags = t
 	}
 
-	data.Content = types.StringValue(wresp.Pages.Single.Content)
+	if data.Normalization.IsNull() {
+		data.Normalization = types.BoolValue(true)
+	}
+	// Content read from a file is only tracked through its hash. Changes
+	// that only come from wiki.js saving the content are no drift, neither is
+	// front matter, which is never saved.
+	editor := wresp.Pages.Single.Editor
+	if data.ContentFile.IsNull() && (data.Content.IsNull() || !data.equalContent(editor, contentBody(data.Content.ValueString()), wresp.Pages.Single.Content)) {
+		data.Content = types.StringValue(wresp.Pages.Single.Content)
+	}
+	data.ContentSha256 = types.StringValue(data.contentSha256(editor, wresp.Pages.Single.Content))
 	data.CreatedAt = types.StringValue(wresp.Pages.Single.CreatedAt)
+	data.UpdatedAt = types.StringValue(wresp.Pages.Single.UpdatedAt)
+	if data.ForceOverwrite.IsNull() {
+		data.ForceOverwrite = types.BoolValue(false)
+	}
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
 	data.Editor = types.StringValue(wresp.Pages.Single.Editor)
 	data.Locale = types.StringValue(wresp.Pages.Single.Locale)
-	data.ScriptCss = types.StringValue(wresp.Pages.Single.ScriptCss)
-	data.ScriptJs = types.StringValue(wresp.Pages.Single.ScriptJs)
+	if data.ScriptCss.IsNull() || !data.equalText(data.ScriptCss.ValueString(), wresp.Pages.Single.ScriptCss) {
+		data.ScriptCss = types.StringValue(wresp.Pages.Single.ScriptCss)
+	}
+	if data.ScriptJs.IsNull() || !data.equalText(data.ScriptJs.ValueString(), wresp.Pages.Single.ScriptJs) {
+		data.ScriptJs = types.StringValue(wresp.Pages.Single.ScriptJs)
+	}
 	data.CreatorId = types.Int64Value(int64(wresp.Pages.Single.CreatorId))
 	data.CreatorName = types.StringValue(wresp.Pages.Single.CreatorName)
 	data.CreatorEmail = types.StringValue(wresp.Pages.Single.CreatorEmail)
@@ -351,6 +509,11 @@ func (r *pageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	content, ok := plannedContent(ctx, data, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
 	var tags []string
 	if data.Tags.IsNull() || data.Tags.IsUnknown() {
 		tags = []string{}
@@ -361,9 +524,183 @@ func (r *pageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
 	}
 
+	var state *pageResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Edits made in wiki.js since the last refresh must not be lost silently.
+	// States written before updated_at was tracked skip the check.
+	if !data.ForceOverwrite.ValueBool() && state.UpdatedAt.ValueString() != "" {
+		conflict, err := pageConflict(ctx, r.client, int(data.Id.ValueInt64()), state.UpdatedAt.ValueString())
+		if err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, "Could not check page for conflicting changes", err)
+			return
+		}
+		if conflict != "" {
+			resp.Diagnostics.AddError("Page was changed in wiki.js", conflict)
+			return
+		}
+	}
+
+	// Moving keeps the history, comments and id of the page
+	if !data.Path.Equal(state.Path) || !data.Locale.Equal(state.Locale) {
+		mresp, err := wikijs.MovePage(ctx, r.client.graphql,
+			int(data.Id.ValueInt64()),
+			data.Path.ValueString(),
+			data.Locale.ValueString(),
+		)
+		if err := responseError(err, &mresp.Pages.Move.ResponseResult); err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, "Could not move page", err)
+			return
+		}
+	}
+
+	// The state hash was normalized for the previous editor
+	unchanged := state.ContentSha256.ValueString() == data.contentSha256(state.Editor.ValueString(), content)
+	if !data.Editor.Equal(state.Editor) && unchanged {
+		cresp, err := wikijs.ConvertPage(ctx, r.client.graphql, int(data.Id.ValueInt64()), data.Editor.ValueString())
+		if err := responseError(err, &cresp.Pages.Convert.ResponseResult); err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, "Could not convert page", err)
+			return
+		}
+
+		// The update below must not overwrite the converted content
+		page, err := wikijs.GetPage(ctx, r.client.graphql, int(data.Id.ValueInt64()))
+		if err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, "Read Page Request failed", err)
+			return
+		}
+		converted := page.Pages.Single.Content
+		if converted != content {
+			resp.Diagnostics.AddAttributeWarning(
+				contentAttribute(data),
+				"Page content was converted",
+				fmt.Sprintf("wiki.js converted the content of page %s to the %s editor. "+
+					"Replace the configured content with the converted content, e.g. from the wikijs_page data source, "+
+					"otherwise the next apply overwrites it.", data.Path.ValueString(), data.Editor.ValueString()),
+			)
+		}
+		content = converted
+	}
+
+	if err := r.update(ctx, data, content, tags); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update page", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// pageContent returns the configured content of the page, read from
+// content_file if set.
+func pageContent(data *pageResourceModel) (string, error) {
+	if data.ContentFile.IsNull() {
+		return data.Content.ValueString(), nil
+	}
+
+	content, err := os.ReadFile(data.ContentFile.ValueString())
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// contentAttribute returns the attribute the content of the page is
+// configured with.
+func contentAttribute(data *pageResourceModel) path.Path {
+	if data.ContentFile.IsNull() {
+		return path.Root("content")
+	}
+
+	return path.Root("content_file")
+}
+
+// plannedContent returns the content to send to wiki.js without its front
+// matter and sets the attributes that were unknown during the plan. A file
+// changed since the plan is rejected, because its hash is already part of
+// the plan.
+func plannedContent(ctx context.Context, data *pageResourceModel, diags *diag.Diagnostics) (string, bool) {
+	content, err := pageContent(data)
+	if err != nil {
+		diags.AddAttributeError(path.Root("content_file"), "Could not read content file", err.Error())
+		return "", false
+	}
+	fm, content, err := splitFrontMatter(content)
+	if err != nil {
+		diags.AddAttributeError(contentAttribute(data), "Could not parse front matter", err.Error())
+		return "", false
+	}
+
+	if fm != nil {
+		fm.fill(ctx, data, diags)
+	}
+	if data.Title.IsUnknown() {
+		diags.AddAttributeError(path.Root("title"), "Missing page title", "Set title in the configuration or in the front matter of the content.")
+		return "", false
+	}
+	if data.Description.IsUnknown() {
+		data.Description = types.StringValue("")
+	}
+	if data.IsPrivate.IsUnknown() {
+		data.IsPrivate = types.BoolValue(false)
+	}
+
+	sum := data.contentSha256(data.Editor.ValueString(), content)
+	if !data.ContentSha256.IsUnknown() && data.ContentSha256.ValueString() != sum {
+		diags.AddAttributeError(
+			path.Root("content_file"),
+			"Content file changed after the plan",
+			fmt.Sprintf("%s changed since the plan was created, plan again to review the change.", data.ContentFile.ValueString()),
+		)
+		return "", false
+	}
+	data.ContentSha256 = types.StringValue(sum)
+
+	return content, true
+}
+
+// contentSha256 returns the hash of content saved with editor.
+func (data *pageResourceModel) contentSha256(editor string, content string) string {
+	if data.normalize() {
+		content = normalizeContent(editor, content)
+	}
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// equalContent reports whether wiki.js returned the configured content,
+// apart from changes it made while saving with editor.
+func (data *pageResourceModel) equalContent(editor string, configured string, saved string) bool {
+	if data.normalize() {
+		return normalizeContent(editor, configured) == normalizeContent(editor, saved)
+	}
+
+	return configured == saved
+}
+
+// equalText is equalContent for plain text like scripts.
+func (data *pageResourceModel) equalText(configured string, saved string) bool {
+	if data.normalize() {
+		return normalizeText(configured) == normalizeText(saved)
+	}
+
+	return configured == saved
+}
+
+func (data *pageResourceModel) normalize() bool {
+	return !data.Normalization.Equal(types.BoolValue(false))
+}
+
+// update saves data to the page with its id and fills in the unknown
+// attributes from the response.
+func (r *pageResource) update(ctx context.Context, data *pageResourceModel, content string, tags []string) error {
 	wresp, err := wikijs.UpdatePage(ctx, r.client.graphql,
 		int(data.Id.ValueInt64()),
-		data.Content.ValueString(),
+		content,
 		data.Description.ValueString(),
 		data.Editor.ValueString(),
 		data.IsPublished.ValueBool(),
@@ -377,13 +714,8 @@ func (r *pageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		tags,
 		data.Title.ValueString(),
 	)
-	if err != nil {
-		resp.Diagnostics.AddError("Update Page Request failed", err.Error())
-		return
-	}
-	if !wresp.Pages.Update.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update page: %s", wresp.Pages.Update.ResponseResult.Slug), wresp.Pages.Update.ResponseResult.Message)
-		return
+	if err := responseError(err, &wresp.Pages.Update.ResponseResult); err != nil {
+		return err
 	}
 
 	if data.Hash.IsUnknown() {
@@ -401,6 +733,7 @@ func (r *pageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if data.CreatedAt.IsUnknown() {
 		data.CreatedAt = types.StringValue(wresp.Pages.Update.Page.CreatedAt)
 	}
+	data.UpdatedAt = types.StringValue(wresp.Pages.Update.Page.UpdatedAt)
 	if data.ScriptCss.IsUnknown() {
 		data.ScriptCss = types.StringValue(wresp.Pages.Update.Page.ScriptCss)
 	}
@@ -417,7 +750,7 @@ func (r *pageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		data.CreatorEmail = types.StringValue(wresp.Pages.Update.Page.CreatorEmail)
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	return nil
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -430,20 +763,47 @@ func (r *pageResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	wresp, err := wikijs.DeletePage(ctx, r.client.graphql, int(data.Id.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("Delete Wiki.js Page Request failed", err.Error())
+	// A page that is already gone needs no deletion
+	if err := responseError(err, &wresp.Pages.Delete.ResponseResult); err != nil && !isNotFound(err) {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not delete Wiki.js page", err)
 		return
 	}
-	if !wresp.Pages.Delete.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not delete Wiki.js page: %s", wresp.Pages.Delete.ResponseResult.Slug), wresp.Pages.Delete.ResponseResult.Message)
-		return
+}
+
+// pageConflict describes the latest version of the page if it was changed
+// after checkoutDate. An empty string is returned if there is no conflict.
+func pageConflict(ctx context.Context, client *WikiJSClient, id int, checkoutDate string) (string, error) {
+	cresp, err := wikijs.CheckPageConflicts(ctx, client.graphql, id, checkoutDate)
+	if err != nil {
+		return "", err
+	}
+	if !cresp.Pages.CheckConflicts {
+		return "", nil
+	}
+
+	lresp, err := wikijs.GetPageConflictLatest(ctx, client.graphql, id)
+	if err != nil {
+		return "", err
 	}
+	latest := lresp.Pages.ConflictLatest
+
+	return fmt.Sprintf("Page %d was changed by %s (id %s) at %s, after Terraform last read it at %s. "+
+		"Run terraform apply again to review the difference, or set force_overwrite to overwrite the changes.\n\n"+
+		"Latest content:\n%s", id, latest.AuthorName, latest.AuthorId, latest.UpdatedAt, checkoutDate, latest.Content), nil
 }
 
+// ImportState imports pages by id or by locale and path, e.g. en/docs/home.
 func (r *pageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	if id, err := strconv.Atoi(req.ID); err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("id"), "Could not parse id", err.Error())
-	} else {
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(id))...)
+	locale, pagePath, ok := strings.Cut(req.ID, "/")
+	if !ok {
+		importIntId(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	wresp, err := wikijs.GetPageByPath(ctx, r.client.graphql, strings.Trim(pagePath, "/"), locale)
+	if err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, fmt.Sprintf("Could not find page %s", req.ID), err)
+		return
 	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(wresp.Pages.SingleByPath.Id))...)
 }
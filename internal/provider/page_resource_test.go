@@ -0,0 +1,424 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccPageResource(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPageResourceConfig(srv, "docs/hello", "Hello", "# Hello"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("wikijs_page.test", "id"),
+					resource.TestCheckResourceAttrSet("wikijs_page.test", "hash"),
+					resource.TestCheckResourceAttrSet("wikijs_page.test", "created_at"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "editor", "markdown"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "is_published", "true"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "creator_email", wikijstest.AdminEmail),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello"),
+				),
+			},
+			{
+				ResourceName:      "wikijs_page.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "wikijs_page.test",
+				ImportState:       true,
+				ImportStateId:     "en/docs/hello",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPageResourceConfig(srv, "docs/hello", "Hello World", "# Hello World"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "title", "Hello World"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "content", "# Hello World"),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello World"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.PageByPath("docs/hello", "en").Content = "edited in the browser"
+					})
+				},
+				Config: testAccPageResourceConfig(srv, "docs/hello", "Hello World", "# Hello World"),
+				Check:  testAccCheckPageContent(srv, "docs/hello", "# Hello World"),
+			},
+			{
+				// Pages deleted in wiki.js are created again
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.Pages = nil })
+				},
+				Config: testAccPageResourceConfig(srv, "docs/hello", "Hello World", "# Hello World"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckPageContent(srv, "docs/hello", "# Hello World"),
+					resource.TestCheckResourceAttrWith("wikijs_page.test", "id", func(value string) error {
+						id = value
+						return nil
+					}),
+				),
+			},
+			{
+				// Changing the path moves the page instead of replacing it
+				Config: testAccPageResourceConfig(srv, "docs/moved", "Hello World", "# Hello World"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "path", "docs/moved"),
+					resource.TestCheckResourceAttrWith("wikijs_page.test", "id", func(value string) error {
+						if value != id {
+							return fmt.Errorf("expected moved page to keep id %s, got %s", id, value)
+						}
+						return nil
+					}),
+					testAccCheckPageContent(srv, "docs/moved", "# Hello World"),
+				),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			var count int
+			srv.Update(func(store *wikijstest.Store) { count = len(store.Pages) })
+			if count != 0 {
+				return fmt.Errorf("expected all pages to be deleted, got %d pages", count)
+			}
+			return nil
+		},
+	})
+}
+
+func testAccPageResourceConfig(srv *wikijstest.Server, path, title, content string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_page" "test" {
+  path        = %q
+  locale      = "en"
+  title       = %q
+  description = "A test page"
+  content     = %q
+  tags        = ["a", "b"]
+}
+`, path, title, content)
+}
+
+func testAccCheckPageContent(srv *wikijstest.Server, path, content string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var page *wikijstest.Page
+		srv.Update(func(store *wikijstest.Store) {
+			if p := store.PageByPath(path, "en"); p != nil {
+				copied := *p
+				page = &copied
+			}
+		})
+		if page == nil {
+			return fmt.Errorf("page %q does not exist", path)
+		}
+		if page.Content != content {
+			return fmt.Errorf("expected content %q, got %q", content, page.Content)
+		}
+		return nil
+	}
+}
+
+func TestAccPageResourceConvert(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPageResourceEditorConfig(srv, "markdown"),
+				Check:  testAccCheckPageEditor(srv, "markdown"),
+			},
+			{
+				Config: testAccPageResourceEditorConfig(srv, "ckeditor"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "editor", "ckeditor"),
+					testAccCheckPageEditor(srv, "ckeditor"),
+				),
+			},
+			{
+				Config:      testAccPageResourceEditorConfig(srv, "wysiwyg"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func testAccPageResourceEditorConfig(srv *wikijstest.Server, editor string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_page" "test" {
+  path        = "docs/hello"
+  locale      = "en"
+  title       = "Hello"
+  description = "A test page"
+  content     = "<p>Hello</p>"
+  editor      = %q
+}
+`, editor)
+}
+
+func testAccCheckPageEditor(srv *wikijstest.Server, editor string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var got string
+		srv.Update(func(store *wikijstest.Store) {
+			if p := store.PageByPath("docs/hello", "en"); p != nil {
+				got = p.Editor
+			}
+		})
+		if got != editor {
+			return fmt.Errorf("expected editor %q, got %q", editor, got)
+		}
+		return nil
+	}
+}
+
+func TestPageConflict(t *testing.T) {
+	srv := testAccServer(t)
+	client := testClient(srv)
+	ctx := context.Background()
+
+	wresp, err := wikijs.CreatePage(ctx, client.graphql, "# Hello", "", "markdown", true, false, "en", "docs/hello", "", "", "", "", []string{}, "Hello")
+	if err := responseError(err, &wresp.Pages.Create.ResponseResult); err != nil {
+		t.Fatal(err)
+	}
+	id := wresp.Pages.Create.Page.Id
+	checkout := wresp.Pages.Create.Page.UpdatedAt
+
+	conflict, err := pageConflict(ctx, client, id, checkout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflict != "" {
+		t.Errorf("expected no conflict for an unchanged page, got: %s", conflict)
+	}
+
+	srv.Update(func(store *wikijstest.Store) {
+		p := store.Page(id)
+		p.Content = "edited in the browser"
+		p.AuthorName = "Editor"
+		p.UpdatedAt = time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	})
+
+	conflict, err = pageConflict(ctx, client, id, checkout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(conflict, "Editor") || !strings.Contains(conflict, "edited in the browser") {
+		t.Errorf("expected conflict with author and latest content, got: %q", conflict)
+	}
+}
+
+func TestAccPageResourceAdoptExisting(t *testing.T) {
+	srv := testAccServer(t)
+
+	wresp, err := wikijs.CreatePage(context.Background(), testClient(srv).graphql, "built by hand", "", "markdown", true, false, "en", "docs/hello", "", "", "", "", []string{}, "Hello")
+	if err := responseError(err, &wresp.Pages.Create.ResponseResult); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPageResourceConfig(srv, "docs/hello", "Hello", "# Hello"),
+				ExpectError: regexp.MustCompile(`PageDuplicateCreate`),
+			},
+			{
+				Config: testAccProviderConfig(srv) + `
+resource "wikijs_page" "test" {
+  path           = "docs/hello"
+  locale         = "en"
+  title          = "Hello"
+  description    = "A test page"
+  content        = "# Hello"
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "id", fmt.Sprint(wresp.Pages.Create.Page.Id)),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPageResourceContentFile(t *testing.T) {
+	srv := testAccServer(t)
+	file := filepath.Join(t.TempDir(), "hello.md")
+	writeFile := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_page" "test" {
+  path         = "docs/hello"
+  locale       = "en"
+  title        = "Hello"
+  description  = "A test page"
+  content_file = %q
+}
+`, file)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { writeFile("# Hello") },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("wikijs_page.test", "content"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "content_sha256", testAccContentSha256("# Hello")),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello"),
+				),
+			},
+			{
+				PreConfig: func() { writeFile("# Hello World") },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "content_sha256", testAccContentSha256("# Hello World")),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello World"),
+				),
+			},
+			{
+				// Changes made in wiki.js are detected through the hash
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.PageByPath("docs/hello", "en").Content = "edited in the browser"
+					})
+				},
+				Config: config,
+				Check:  testAccCheckPageContent(srv, "docs/hello", "# Hello World"),
+			},
+		},
+	})
+}
+
+func testAccContentSha256(content string) string {
+	return (&pageResourceModel{}).contentSha256("markdown", content)
+}
+
+func TestAccPageResourceNormalization(t *testing.T) {
+	srv := testAccServer(t)
+	config := func(normalization bool) string {
+		return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_page" "test" {
+  path                  = "docs/hello"
+  locale                = "en"
+  title                 = "Hello"
+  description           = "A test page"
+  content               = "<ul>\n  <li>a</li>\n</ul>\n"
+  editor                = "ckeditor"
+  script_css            = "p {}\n"
+  content_normalization = %t
+}
+`, normalization)
+	}
+	resave := func() {
+		srv.Update(func(store *wikijstest.Store) {
+			p := store.PageByPath("docs/hello", "en")
+			p.Content = "<ul><li>a</li></ul>"
+			p.ScriptCss = "p {}"
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+			},
+			{
+				// Content re-serialized by wiki.js is no drift
+				PreConfig: resave,
+				Config:    config(true),
+				PlanOnly:  true,
+			},
+			{
+				Config: config(false),
+				Check:  testAccCheckPageContent(srv, "docs/hello", "<ul>\n  <li>a</li>\n</ul>\n"),
+			},
+			{
+				PreConfig:          resave,
+				Config:             config(false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccPageResourceFrontMatter(t *testing.T) {
+	srv := testAccServer(t)
+	config := func(content string) string {
+		return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_page" "test" {
+  path    = "docs/hello"
+  locale  = "en"
+  content = %q
+}
+`, content)
+	}
+	content := "---\ntitle: Hello\ndescription: A test page\ntags: [a, b]\nis_private: true\n---\n# Hello"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(content),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "title", "Hello"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "description", "A test page"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "is_private", "true"),
+					resource.TestCheckResourceAttr("wikijs_page.test", "content", content),
+					resource.TestCheckResourceAttr("wikijs_page.test", "content_sha256", testAccContentSha256("# Hello")),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello"),
+				),
+			},
+			{
+				Config: config(strings.Replace(content, "title: Hello", "title: Hello World", 1)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page.test", "title", "Hello World"),
+					testAccCheckPageContent(srv, "docs/hello", "# Hello"),
+				),
+			},
+			{
+				Config:      config("# Hello"),
+				ExpectError: regexp.MustCompile("Missing page title"),
+			},
+		},
+	})
+}
+
+func TestContentAttribute(t *testing.T) {
+	data := &pageResourceModel{Content: types.StringValue("# Hello"), ContentFile: types.StringNull()}
+	if got := contentAttribute(data); !got.Equal(path.Root("content")) {
+		t.Errorf("expected content, got %s", got)
+	}
+
+	data = &pageResourceModel{Content: types.StringNull(), ContentFile: types.StringValue("hello.md")}
+	if got := contentAttribute(data); !got.Equal(path.Root("content_file")) {
+		t.Errorf("expected content_file, got %s", got)
+	}
+}
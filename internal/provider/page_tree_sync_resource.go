@@ -0,0 +1,651 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &pageTreeSyncResource{}
+	_ resource.ResourceWithConfigure  = &pageTreeSyncResource{}
+	_ resource.ResourceWithModifyPlan = &pageTreeSyncResource{}
+)
+
+// pageTreeEditors maps the extensions of synced files to the editor of their
+// pages. Files with other extensions are ignored.
+var pageTreeEditors = map[string]string{
+	".md":   "markdown",
+	".html": "code",
+	".adoc": "asciidoc",
+}
+
+// NewPageTreeSyncResource is a helper function to simplify the provider implementation.
+func NewPageTreeSyncResource() resource.Resource {
+	return &pageTreeSyncResource{}
+}
+
+// pageTreeSyncResource is the resource implementation.
+type pageTreeSyncResource struct {
+	client *WikiJSClient
+}
+
+type pageTreeSyncResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	SourceDir     types.String `tfsdk:"source_dir"`
+	Locale        types.String `tfsdk:"locale"`
+	PathPrefix    types.String `tfsdk:"path_prefix"`
+	DeleteRemoved types.Bool   `tfsdk:"delete_removed"`
+	Pages         types.Map    `tfsdk:"pages"`
+}
+
+type pageTreeSyncPageModel struct {
+	Id     types.Int64  `tfsdk:"id"`
+	Path   types.String `tfsdk:"path"`
+	Sha256 types.String `tfsdk:"sha256"`
+}
+
+var pageTreeSyncPageType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":     types.Int64Type,
+	"path":   types.StringType,
+	"sha256": types.StringType,
+}}
+
+// Metadata returns the resource type name.
+func (r *pageTreeSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page_tree_sync"
+}
+
+// Schema defines the schema for the resource.
+func (r *pageTreeSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Locale and path prefix of the synced pages",
+			},
+			"source_dir": schema.StringAttribute{
+				Required:    true,
+				Description: "Local directory with the page files",
+			},
+			"locale": schema.StringAttribute{
+				Required:    true,
+				Description: "Language of the synced pages. Changing it moves all pages.",
+			},
+			"path_prefix": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Path the synced pages are placed under (omit leading slash). Changing it moves all pages.",
+				Default:     stringdefault.StaticString(""),
+			},
+			"delete_removed": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Delete the pages of removed files, and all synced pages when the resource is destroyed. By default they are only no longer managed.",
+				Default:     booldefault.StaticBool(false),
+			},
+			"pages": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Synced pages by the path of their file relative to source_dir",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Internal id of the page",
+						},
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path of the page",
+						},
+						"sha256": schema.StringAttribute{
+							Computed:    true,
+							Description: "Hex encoded SHA-256 hash of the content and attributes of the page, after content normalization",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: "The `wikijs_page_tree_sync` resource publishes a local directory of pages, e.g. a handbook kept in git.\n" +
+			"Every `.md` (markdown), `.html` (code) and `.adoc` (asciidoc) file is synced to the page at `path_prefix` followed by its path relative to `source_dir` without the extension.\n" +
+			"Files and directories starting with a dot are ignored.\n" +
+			"\n" +
+			"Title, description, tags, is_private, publish dates and scripts are read from YAML front matter like in `wikijs_page`, the title defaults to the file name.\n" +
+			"Pages are created, updated and moved so the wiki matches the directory, renamed files whose content did not change keep their page.\n" +
+			"Existing pages at the path of a new file are updated instead of created.\n" +
+			"Pages of removed files are only deleted with `delete_removed`.",
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *pageTreeSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*WikiJSClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *WikiJSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan plans the pages from the files in source_dir. Pages of files
+// that are already synced keep their id.
+func (r *pageTreeSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *pageTreeSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Locale.IsUnknown() && !plan.PathPrefix.IsUnknown() {
+		plan.Id = types.StringValue(pageTreeSyncId(plan))
+	}
+	if plan.SourceDir.IsUnknown() || plan.PathPrefix.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	files := scanPageTree(plan.SourceDir.ValueString(), plan.PathPrefix.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	synced := map[string]pageTreeSyncPageModel{}
+	if !req.State.Raw.IsNull() {
+		var state *pageTreeSyncResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(state.Pages.ElementsAs(ctx, &synced, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	pages := map[string]pageTreeSyncPageModel{}
+	for file, p := range files {
+		id := types.Int64Unknown()
+		if s, ok := synced[file]; ok {
+			id = s.Id
+		}
+		pages[file] = pageTreeSyncPageModel{
+			Id:     id,
+			Path:   types.StringValue(p.path),
+			Sha256: types.StringValue(p.sha256()),
+		}
+	}
+
+	value, diags := types.MapValueFrom(ctx, pageTreeSyncPageType, pages)
+	resp.Diagnostics.Append(diags...)
+	plan.Pages = value
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *pageTreeSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data *pageTreeSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, data, data.Locale.ValueString(), map[string]pageTreeSyncPageModel{}, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *pageTreeSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *pageTreeSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var synced map[string]pageTreeSyncPageModel
+	resp.Diagnostics.Append(data.Pages.ElementsAs(ctx, &synced, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Pages deleted in wiki.js are created again, changes made in wiki.js
+	// show up as a changed hash.
+	pages := map[string]pageTreeSyncPageModel{}
+	for file, s := range synced {
+		wresp, err := wikijs.GetPage(ctx, r.client.graphql, int(s.Id.ValueInt64()))
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			addErrorDiagnostic(&resp.Diagnostics, fmt.Sprintf("Could not read page of %s", file), err)
+			pages[file] = s
+			continue
+		}
+
+		s.Path = types.StringValue(wresp.Pages.Single.Path)
+		s.Sha256 = types.StringValue(savedTreePage(wresp.Pages.Single).sha256())
+		pages[file] = s
+	}
+
+	value, diags := types.MapValueFrom(ctx, pageTreeSyncPageType, pages)
+	resp.Diagnostics.Append(diags...)
+	data.Pages = value
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *pageTreeSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data, state *pageTreeSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var synced map[string]pageTreeSyncPageModel
+	resp.Diagnostics.Append(state.Pages.ElementsAs(ctx, &synced, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, data, state.Locale.ValueString(), synced, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *pageTreeSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *pageTreeSyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.DeleteRemoved.ValueBool() {
+		return
+	}
+
+	var synced map[string]pageTreeSyncPageModel
+	resp.Diagnostics.Append(data.Pages.ElementsAs(ctx, &synced, false)...)
+	for file, s := range synced {
+		if err := deleteTreePage(ctx, r.client, s); err != nil {
+			addErrorDiagnostic(&resp.Diagnostics, fmt.Sprintf("Could not delete page of %s", file), err)
+		}
+	}
+}
+
+// sync creates, moves, updates and deletes pages until they match the files
+// in the source directory, starting from the pages synced to syncedLocale.
+// The pages of data are set to the pages synced successfully, so failed
+// files are retried by the next apply.
+func (r *pageTreeSyncResource) sync(ctx context.Context, data *pageTreeSyncResourceModel, syncedLocale string, synced map[string]pageTreeSyncPageModel, diags *diag.Diagnostics) {
+	locale := data.Locale.ValueString()
+	data.Id = types.StringValue(pageTreeSyncId(data))
+
+	// The plan is unknown if source_dir was unknown during the plan
+	var planned map[string]pageTreeSyncPageModel
+	if !data.Pages.IsUnknown() {
+		diags.Append(data.Pages.ElementsAs(ctx, &planned, false)...)
+	}
+	files := scanPageTree(data.SourceDir.ValueString(), data.PathPrefix.ValueString(), diags)
+	pages := map[string]pageTreeSyncPageModel{}
+	defer func() {
+		value, d := types.MapValueFrom(ctx, pageTreeSyncPageType, pages)
+		diags.Append(d...)
+		data.Pages = value
+	}()
+	if diags.HasError() {
+		maps.Copy(pages, synced)
+		return
+	}
+
+	// Files that were renamed without changing their content keep their page,
+	// files with the same content are matched in order
+	removed := map[string][]string{}
+	for _, file := range slices.Sorted(maps.Keys(synced)) {
+		s := synced[file]
+		_, exists := files[file]
+		_, wasPlanned := planned[file]
+		if !exists && wasPlanned {
+			diags.AddAttributeError(
+				path.Root("pages").AtMapKey(file),
+				"File removed after the plan",
+				fmt.Sprintf("%s was removed since the plan was created, plan again to review the change.", file),
+			)
+			pages[file] = s
+		} else if !exists {
+			removed[s.Sha256.ValueString()] = append(removed[s.Sha256.ValueString()], file)
+		}
+	}
+
+	for _, file := range slices.Sorted(maps.Keys(files)) {
+		p := files[file]
+		sum := p.sha256()
+		s, ok := synced[file]
+		if planned != nil && planned[file].Sha256.ValueString() != sum {
+			diags.AddAttributeError(
+				path.Root("pages").AtMapKey(file),
+				"File changed after the plan",
+				fmt.Sprintf("%s changed since the plan was created, plan again to review the change.", file),
+			)
+			if ok {
+				pages[file] = s
+			}
+			continue
+		}
+		move := locale != syncedLocale
+		if renamed := removed[sum]; !ok && len(renamed) > 0 {
+			s, ok = synced[renamed[0]], true
+			removed[sum] = renamed[1:]
+		}
+
+		// Pages left behind by an apply that failed halfway, e.g. because
+		// the resource was tainted, are taken over instead of failing with a
+		// duplicate path
+		if !ok {
+			existing, err := wikijs.GetPageByPath(ctx, r.client.graphql, p.path, locale)
+			if err != nil && !isNotFound(err) {
+				addErrorDiagnostic(diags, fmt.Sprintf("Could not look up existing page of %s", file), err)
+				continue
+			}
+			if err == nil {
+				s, ok, move = pageTreeSyncPageModel{
+					Id:   types.Int64Value(int64(existing.Pages.SingleByPath.Id)),
+					Path: types.StringValue(p.path),
+				}, true, false
+				diags.AddAttributeWarning(
+					path.Root("pages").AtMapKey(file),
+					"Adopted existing page",
+					fmt.Sprintf("Page %d already existed at %s/%s and was updated with %s instead of being created.",
+						existing.Pages.SingleByPath.Id, locale, p.path, file),
+				)
+			}
+		}
+
+		if !ok {
+			id, err := createTreePage(ctx, r.client, locale, p)
+			if err != nil {
+				addErrorDiagnostic(diags, fmt.Sprintf("Could not create page of %s", file), err)
+				continue
+			}
+			s.Id = types.Int64Value(int64(id))
+		} else if err := updateTreePage(ctx, r.client, locale, move, s, p); err != nil {
+			addErrorDiagnostic(diags, fmt.Sprintf("Could not update page of %s", file), err)
+			if _, ok := synced[file]; ok {
+				pages[file] = synced[file]
+			}
+			continue
+		}
+		s.Path = types.StringValue(p.path)
+		s.Sha256 = types.StringValue(sum)
+		pages[file] = s
+	}
+
+	if data.DeleteRemoved.ValueBool() {
+		for _, files := range removed {
+			for _, file := range files {
+				if err := deleteTreePage(ctx, r.client, synced[file]); err != nil {
+					addErrorDiagnostic(diags, fmt.Sprintf("Could not delete page of %s", file), err)
+					pages[file] = synced[file]
+				}
+			}
+		}
+	}
+}
+
+// pageTreeSyncId returns the locale and path prefix of data, e.g. en/docs.
+func pageTreeSyncId(data *pageTreeSyncResourceModel) string {
+	return data.Locale.ValueString() + "/" + strings.Trim(data.PathPrefix.ValueString(), "/")
+}
+
+func createTreePage(ctx context.Context, client *WikiJSClient, locale string, p *treePage) (int, error) {
+	wresp, err := wikijs.CreatePage(ctx, client.graphql,
+		p.Content,
+		p.Description,
+		p.Editor,
+		true,
+		p.IsPrivate,
+		locale,
+		p.path,
+		p.PublishEndDate,
+		p.PublishStartDate,
+		p.ScriptCss,
+		p.ScriptJs,
+		p.Tags,
+		p.Title,
+	)
+	if err := responseError(err, &wresp.Pages.Create.ResponseResult); err != nil {
+		return 0, err
+	}
+
+	return wresp.Pages.Create.Page.Id, nil
+}
+
+// updateTreePage moves the synced page s to the path of p or to locale if
+// needed and saves p to it if it changed.
+func updateTreePage(ctx context.Context, client *WikiJSClient, locale string, move bool, s pageTreeSyncPageModel, p *treePage) error {
+	id := int(s.Id.ValueInt64())
+	if move || s.Path.ValueString() != p.path {
+		mresp, err := wikijs.MovePage(ctx, client.graphql, id, p.path, locale)
+		if err := responseError(err, &mresp.Pages.Move.ResponseResult); err != nil {
+			return err
+		}
+	}
+	if s.Sha256.ValueString() == p.sha256() {
+		return nil
+	}
+
+	wresp, err := wikijs.UpdatePage(ctx, client.graphql,
+		id,
+		p.Content,
+		p.Description,
+		p.Editor,
+		true,
+		p.IsPrivate,
+		locale,
+		p.path,
+		p.PublishEndDate,
+		p.PublishStartDate,
+		p.ScriptCss,
+		p.ScriptJs,
+		p.Tags,
+		p.Title,
+	)
+
+	return responseError(err, &wresp.Pages.Update.ResponseResult)
+}
+
+// deleteTreePage deletes the synced page s, pages that are already gone need
+// no deletion.
+func deleteTreePage(ctx context.Context, client *WikiJSClient, s pageTreeSyncPageModel) error {
+	wresp, err := wikijs.DeletePage(ctx, client.graphql, int(s.Id.ValueInt64()))
+	if err := responseError(err, &wresp.Pages.Delete.ResponseResult); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// treePage is a page as it is synced from a file. Its hash covers everything
+// that is saved to wiki.js, so it can be compared with the page read back.
+type treePage struct {
+	path string
+
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Tags             []string `json:"tags"`
+	IsPrivate        bool     `json:"is_private"`
+	PublishStartDate string   `json:"publish_start_date"`
+	PublishEndDate   string   `json:"publish_end_date"`
+	ScriptCss        string   `json:"script_css"`
+	ScriptJs         string   `json:"script_js"`
+	Editor           string   `json:"editor"`
+	Content          string   `json:"content"`
+}
+
+// sha256 returns the hash of the page, apart from the differences wiki.js
+// introduces when it saves a page.
+func (p *treePage) sha256() string {
+	normalized := *p
+	normalized.Tags = append([]string{}, p.Tags...)
+	slices.Sort(normalized.Tags)
+	normalized.ScriptCss = normalizeText(p.ScriptCss)
+	normalized.ScriptJs = normalizeText(p.ScriptJs)
+	normalized.Content = normalizeContent(p.Editor, p.Content)
+
+	// Marshalling strings, bools and slices of them cannot fail
+	b, _ := json.Marshal(normalized)
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// savedTreePage returns the synced attributes of a page read from wiki.js.
+func savedTreePage(page wikijs.GetPagePagesPageQuerySinglePage) *treePage {
+	tags := make([]string, 0, len(page.Tags))
+	for _, t := range page.Tags {
+		tags = append(tags, t.Tag)
+	}
+
+	return &treePage{
+		path:             page.Path,
+		Title:            page.Title,
+		Description:      page.Description,
+		Tags:             tags,
+		IsPrivate:        page.IsPrivate,
+		PublishStartDate: page.PublishStartDate,
+		PublishEndDate:   page.PublishEndDate,
+		ScriptCss:        page.ScriptCss,
+		ScriptJs:         page.ScriptJs,
+		Editor:           page.Editor,
+		Content:          page.Content,
+	}
+}
+
+// scanPageTree reads the page files below dir by their slash separated path
+// relative to dir. Files that cannot be read are reported in diags.
+func scanPageTree(dir string, prefix string, diags *diag.Diagnostics) map[string]*treePage {
+	prefix = strings.Trim(prefix, "/")
+	files := map[string]*treePage{}
+	paths := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		editor, ok := pageTreeEditors[filepath.Ext(name)]
+		if entry.IsDir() || !ok {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		file := filepath.ToSlash(rel)
+		p, err := readTreePage(name, editor)
+		if err != nil {
+			diags.AddAttributeError(path.Root("pages").AtMapKey(file), fmt.Sprintf("Could not read %s", file), err.Error())
+			return nil
+		}
+		p.path = strings.TrimSuffix(file, filepath.Ext(file))
+		if prefix != "" {
+			p.path = prefix + "/" + p.path
+		}
+		if other, ok := paths[p.path]; ok {
+			diags.AddAttributeError(
+				path.Root("pages").AtMapKey(file),
+				fmt.Sprintf("Duplicate page path %s", p.path),
+				fmt.Sprintf("%s and %s are both synced to %s, rename one of them.", other, file, p.path),
+			)
+			return nil
+		}
+		paths[p.path] = file
+		files[file] = p
+
+		return nil
+	})
+	if err != nil {
+		diags.AddAttributeError(path.Root("source_dir"), "Could not read source directory", err.Error())
+	}
+
+	return files
+}
+
+// readTreePage reads a page file and the attributes in its front matter.
+func readTreePage(name string, editor string) (*treePage, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	fm, body, err := splitFrontMatter(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &treePage{
+		Title:   strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)),
+		Tags:    []string{},
+		Editor:  editor,
+		Content: body,
+	}
+	if fm == nil {
+		return p, nil
+	}
+	for _, f := range []struct {
+		value *string
+		field *string
+	}{
+		{fm.Title, &p.Title},
+		{fm.Description, &p.Description},
+		{fm.PublishStartDate, &p.PublishStartDate},
+		{fm.PublishEndDate, &p.PublishEndDate},
+		{fm.ScriptCss, &p.ScriptCss},
+		{fm.ScriptJs, &p.ScriptJs},
+	} {
+		if f.value != nil {
+			*f.field = *f.value
+		}
+	}
+	if fm.IsPrivate != nil {
+		p.IsPrivate = *fm.IsPrivate
+	}
+	if fm.Tags != nil {
+		p.Tags = fm.Tags
+	}
+
+	return p, nil
+}
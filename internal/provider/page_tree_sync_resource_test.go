@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func writeTreeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	name = filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanPageTree(t *testing.T) {
+	dir := t.TempDir()
+	writeTreeFile(t, dir, "index.md", "# Handbook")
+	writeTreeFile(t, dir, "team/oncall.md", "---\ntitle: On-call\ntags: [runbook]\n---\n# On-call")
+	writeTreeFile(t, dir, "team/diagram.html", "<p>Diagram</p>")
+	writeTreeFile(t, dir, "team/notes.txt", "ignored")
+	writeTreeFile(t, dir, ".git/HEAD.md", "ignored")
+
+	var diags diag.Diagnostics
+	files := scanPageTree(dir, "/docs/", &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	for file, want := range map[string]treePage{
+		"index.md":          {path: "docs/index", Title: "index", Editor: "markdown", Content: "# Handbook"},
+		"team/oncall.md":    {path: "docs/team/oncall", Title: "On-call", Editor: "markdown", Content: "# On-call"},
+		"team/diagram.html": {path: "docs/team/diagram", Title: "diagram", Editor: "code", Content: "<p>Diagram</p>"},
+	} {
+		got, ok := files[file]
+		if !ok {
+			t.Errorf("expected %s to be synced", file)
+			continue
+		}
+		if got.path != want.path || got.Title != want.Title || got.Editor != want.Editor || got.Content != want.Content {
+			t.Errorf("%s: expected %+v, got %+v", file, want, *got)
+		}
+	}
+	if len(files) != 3 {
+		t.Errorf("expected 3 files, got %d", len(files))
+	}
+
+	writeTreeFile(t, dir, "index.html", "<p>Handbook</p>")
+	diags = nil
+	scanPageTree(dir, "docs", &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for two files with the same page path")
+	}
+}
+
+func TestTreePageSha256(t *testing.T) {
+	a := &treePage{Title: "Hello", Tags: []string{"b", "a"}, Editor: "markdown", Content: "# Hello\n", ScriptCss: "p {}\r\n"}
+	b := &treePage{Title: "Hello", Tags: []string{"a", "b"}, Editor: "markdown", Content: "# Hello", ScriptCss: "p {}"}
+	if a.sha256() != b.sha256() {
+		t.Error("expected tag order and saving differences to be ignored")
+	}
+
+	b.Description = "changed"
+	if a.sha256() == b.sha256() {
+		t.Error("expected changed attributes to change the hash")
+	}
+}
+
+func TestPageTreeSync(t *testing.T) {
+	srv := testAccServer(t)
+	r := &pageTreeSyncResource{client: testClient(srv)}
+	ctx := context.Background()
+	dir := t.TempDir()
+	data := &pageTreeSyncResourceModel{
+		SourceDir:     types.StringValue(dir),
+		Locale:        types.StringValue("en"),
+		PathPrefix:    types.StringValue("docs"),
+		DeleteRemoved: types.BoolValue(true),
+		Pages:         types.MapUnknown(pageTreeSyncPageType),
+	}
+	sync := func() map[string]pageTreeSyncPageModel {
+		t.Helper()
+
+		var synced map[string]pageTreeSyncPageModel
+		if !data.Pages.IsUnknown() {
+			data.Pages.ElementsAs(ctx, &synced, false)
+		}
+		var diags diag.Diagnostics
+		data.Pages = types.MapUnknown(pageTreeSyncPageType)
+		r.sync(ctx, data, "en", synced, &diags)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		pages := map[string]pageTreeSyncPageModel{}
+		data.Pages.ElementsAs(ctx, &pages, false)
+		return pages
+	}
+	page := func(path string) *wikijstest.Page {
+		var page *wikijstest.Page
+		srv.Update(func(store *wikijstest.Store) {
+			if p := store.PageByPath(path, "en"); p != nil {
+				copied := *p
+				page = &copied
+			}
+		})
+		return page
+	}
+
+	writeTreeFile(t, dir, "a.md", "---\ntitle: A\n---\n# A")
+	writeTreeFile(t, dir, "b.md", "# B")
+	pages := sync()
+	if len(pages) != 2 || page("docs/a") == nil || page("docs/a").Title != "A" || page("docs/b").Content != "# B" {
+		t.Fatalf("expected pages a and b to be created, got %v", pages)
+	}
+	id := pages["a.md"].Id
+
+	// Pages read back from wiki.js have the hash of their file
+	wresp, err := wikijs.GetPage(ctx, r.client.graphql, int(id.ValueInt64()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum := savedTreePage(wresp.Pages.Single).sha256(); sum != pages["a.md"].Sha256.ValueString() {
+		t.Errorf("expected saved page to have hash %s, got %s", pages["a.md"].Sha256, sum)
+	}
+
+	// Renamed files keep their page
+	if err := os.Rename(filepath.Join(dir, "a.md"), filepath.Join(dir, "c.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeTreeFile(t, dir, "b.md", "# B changed")
+	pages = sync()
+	if !pages["c.md"].Id.Equal(id) || page("docs/a") != nil || page("docs/c") == nil {
+		t.Errorf("expected page a to be moved to c, got %v", pages)
+	}
+	if page("docs/b").Content != "# B changed" {
+		t.Errorf("expected page b to be updated, got %q", page("docs/b").Content)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "b.md")); err != nil {
+		t.Fatal(err)
+	}
+	pages = sync()
+	if _, ok := pages["b.md"]; ok || page("docs/b") != nil {
+		t.Errorf("expected page b to be deleted, got %v", pages)
+	}
+
+	// Renamed files with the same content keep one page each
+	same := "---\ntitle: Same\n---\nsame"
+	writeTreeFile(t, dir, "d.md", same)
+	writeTreeFile(t, dir, "e.md", same)
+	pages = sync()
+	ids := map[int64]bool{pages["d.md"].Id.ValueInt64(): true, pages["e.md"].Id.ValueInt64(): true}
+	for from, to := range map[string]string{"d.md": "f.md", "e.md": "g.md"} {
+		if err := os.Rename(filepath.Join(dir, from), filepath.Join(dir, to)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pages = sync()
+	if !ids[pages["f.md"].Id.ValueInt64()] || !ids[pages["g.md"].Id.ValueInt64()] || pages["f.md"].Id.Equal(pages["g.md"].Id) {
+		t.Errorf("expected pages d and e to be moved, got %v", pages)
+	}
+	if page("docs/d") != nil || page("docs/e") != nil || len(pages) != 3 {
+		t.Errorf("expected no pages to be left behind, got %v", pages)
+	}
+}
+
+func TestPageTreeSyncAdoptsExistingPages(t *testing.T) {
+	srv := testAccServer(t)
+	r := &pageTreeSyncResource{client: testClient(srv)}
+	ctx := context.Background()
+	dir := t.TempDir()
+	data := &pageTreeSyncResourceModel{
+		SourceDir:  types.StringValue(dir),
+		Locale:     types.StringValue("en"),
+		PathPrefix: types.StringValue("docs"),
+		Pages:      types.MapUnknown(pageTreeSyncPageType),
+	}
+
+	// A page created by an earlier apply that did not make it into the state
+	id, err := createTreePage(ctx, r.client, "en", &treePage{path: "docs/a", Title: "A", Tags: []string{}, Editor: "markdown", Content: "# Old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTreeFile(t, dir, "a.md", "# A")
+	var diags diag.Diagnostics
+	r.sync(ctx, data, "en", nil, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected the page to be adopted with a warning, got %v", diags)
+	}
+
+	var pages map[string]pageTreeSyncPageModel
+	data.Pages.ElementsAs(ctx, &pages, false)
+	if pages["a.md"].Id.ValueInt64() != int64(id) {
+		t.Errorf("expected page %d to be adopted, got %v", id, pages)
+	}
+	srv.Update(func(store *wikijstest.Store) {
+		if content := store.PageByPath("docs/a", "en").Content; content != "# A" {
+			t.Errorf("expected adopted page to be updated, got %q", content)
+		}
+	})
+}
+
+func TestAccPageTreeSyncResource(t *testing.T) {
+	srv := testAccServer(t)
+	dir := t.TempDir()
+	config := testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_page_tree_sync" "test" {
+  source_dir     = %q
+  locale         = "en"
+  path_prefix    = "docs"
+  delete_removed = true
+}
+`, dir)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					writeTreeFile(t, dir, "index.md", "# Handbook")
+					writeTreeFile(t, dir, "team/oncall.md", "---\ntitle: On-call\n---\n# On-call")
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page_tree_sync.test", "id", "en/docs"),
+					resource.TestCheckResourceAttr("wikijs_page_tree_sync.test", "pages.%", "2"),
+					resource.TestCheckResourceAttr("wikijs_page_tree_sync.test", "pages.team/oncall.md.path", "docs/team/oncall"),
+					testAccCheckPageContent(srv, "docs/team/oncall", "# On-call"),
+				),
+			},
+			{
+				// Changes made in wiki.js are detected through the hash
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.PageByPath("docs/index", "en").Content = "edited in the browser"
+					})
+				},
+				Config: config,
+				Check:  testAccCheckPageContent(srv, "docs/index", "# Handbook"),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(dir, "index.md")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_page_tree_sync.test", "pages.%", "1"),
+					func(*terraform.State) error {
+						var exists bool
+						srv.Update(func(store *wikijstest.Store) {
+							exists = store.PageByPath("docs/index", "en") != nil
+						})
+						if exists {
+							return fmt.Errorf("expected page of removed file to be deleted")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
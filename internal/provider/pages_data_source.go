@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &pagesDataSource{}
+	_ datasource.DataSourceWithConfigure = &pagesDataSource{}
+)
+
+// NewPagesDataSource is a helper function to simplify the provider implementation.
+func NewPagesDataSource() datasource.DataSource {
+	return &pagesDataSource{}
+}
+
+// pagesDataSource is the data source implementation.
+type pagesDataSource struct {
+	client *WikiJSClient
+}
+
+// pagesDataSourceModel maps the data source schema data.
+type pagesDataSourceModel struct {
+	Tags             []types.String     `tfsdk:"tags"`
+	Locale           types.String       `tfsdk:"locale"`
+	CreatorId        types.Int64        `tfsdk:"creator_id"`
+	AuthorId         types.Int64        `tfsdk:"author_id"`
+	PathPrefix       types.String       `tfsdk:"path_prefix"`
+	OrderBy          types.String       `tfsdk:"order_by"`
+	OrderByDirection types.String       `tfsdk:"order_by_direction"`
+	Limit            types.Int64        `tfsdk:"limit"`
+	Pages            []pageMinimalModel `tfsdk:"pages"`
+}
+
+type pageMinimalModel struct {
+	Id          types.Int64    `tfsdk:"id"`
+	Path        types.String   `tfsdk:"path"`
+	Locale      types.String   `tfsdk:"locale"`
+	Title       types.String   `tfsdk:"title"`
+	Tags        []types.String `tfsdk:"tags"`
+	IsPublished types.Bool     `tfsdk:"is_published"`
+	UpdatedAt   types.String   `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *pagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pages"
+}
+
+// Schema defines the schema for the data source.
+func (d *pagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only list pages that have all of these tags",
+			},
+			"locale": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list pages in this language",
+			},
+			"creator_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list pages created by this user. Together with author_id, pages created by this user or last edited by the author are listed.",
+			},
+			"author_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list pages last edited by this user",
+			},
+			"path_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the page at this path and the pages below it (omit leading slash)",
+			},
+			"order_by": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sort pages by `CREATED`, `ID` (default), `PATH`, `TITLE` or `UPDATED`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(wikijs.PageOrderByCreated),
+						string(wikijs.PageOrderById),
+						string(wikijs.PageOrderByPath),
+						string(wikijs.PageOrderByTitle),
+						string(wikijs.PageOrderByUpdated),
+					),
+				},
+			},
+			"order_by_direction": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sort direction, `ASC` (default) or `DESC`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(wikijs.PageOrderByDirectionAsc), string(wikijs.PageOrderByDirectionDesc)),
+				},
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of pages to list",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"pages": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Internal id of the page",
+						},
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path of the page",
+						},
+						"locale": schema.StringAttribute{
+							Computed:    true,
+							Description: "Language of the page",
+						},
+						"title": schema.StringAttribute{
+							Computed:    true,
+							Description: "Page Title",
+						},
+						"tags": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "List of page tags",
+						},
+						"is_published": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether this page is published",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Date of the last change of this page (expect RFC 3399 timestamp)",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pagesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*WikiJSClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *WikiJSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pagesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags := make([]string, 0, len(state.Tags))
+	for _, t := range state.Tags {
+		tags = append(tags, t.ValueString())
+	}
+	prefix := strings.TrimPrefix(state.PathPrefix.ValueString(), "/")
+
+	// wiki.js applies the limit before it filters by tags, and does not
+	// filter by path at all, so the limit is applied here in these cases
+	limit := int(state.Limit.ValueInt64())
+	serverLimit := limit
+	if len(tags) > 0 || prefix != "" {
+		serverLimit = 0
+	}
+
+	wresp, err := wikijs.ListPages(ctx, d.client.graphql,
+		serverLimit,
+		wikijs.PageOrderBy(state.OrderBy.ValueString()),
+		wikijs.PageOrderByDirection(state.OrderByDirection.ValueString()),
+		tags,
+		state.Locale.ValueString(),
+		int(state.CreatorId.ValueInt64()),
+		int(state.AuthorId.ValueInt64()),
+	)
+	if err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Get Page List Query failed", err)
+		return
+	}
+
+	state.Pages = []pageMinimalModel{}
+	for _, p := range wresp.Pages.List {
+		if !hasPathPrefix(p.Path, prefix) {
+			continue
+		}
+		if limit > 0 && len(state.Pages) == limit {
+			break
+		}
+
+		pageTags := []types.String{}
+		for _, t := range p.Tags {
+			pageTags = append(pageTags, types.StringValue(t))
+		}
+		state.Pages = append(state.Pages, pageMinimalModel{
+			Id:          types.Int64Value(int64(p.Id)),
+			Path:        types.StringValue(p.Path),
+			Locale:      types.StringValue(p.Locale),
+			Title:       types.StringValue(p.Title),
+			Tags:        pageTags,
+			IsPublished: types.BoolValue(p.IsPublished),
+			UpdatedAt:   types.StringValue(p.UpdatedAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// hasPathPrefix reports whether path is prefix or below it, so docs matches
+// docs/team but not docs-old.
+func hasPathPrefix(path string, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccPagesDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	srv.Update(func(store *wikijstest.Store) {
+		for i, path := range []string{"docs/runbooks/restore", "docs/team", "docs/runbooks/backup", "guides/start", "docs-old/team"} {
+			store.Pages = append(store.Pages, &wikijstest.Page{
+				Id:          100 + i,
+				Path:        path,
+				Title:       path,
+				Locale:      "en",
+				Tags:        []string{"runbook"},
+				IsPublished: true,
+			})
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "wikijs_pages" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.#", "5"),
+					resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.0.id", "100"),
+					resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.0.tags.0", "runbook"),
+					resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.0.is_published", "true"),
+				),
+			},
+			{
+				// The limit applies after the pages are filtered by path
+				Config: testAccProviderConfig(srv) + `
+data "wikijs_pages" "test" {
+  tags        = ["runbook"]
+  path_prefix = "docs/runbooks/"
+  order_by    = "PATH"
+  limit       = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.#", "1"),
+					resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.0.path", "docs/runbooks/backup"),
+				),
+			},
+			{
+				// Sibling paths sharing the prefix are not below it
+				Config: testAccProviderConfig(srv) + `
+data "wikijs_pages" "test" {
+  path_prefix = "docs"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.wikijs_pages.test", "pages.#", "3"),
+			},
+		},
+	})
+}
+
+func TestHasPathPrefix(t *testing.T) {
+	tests := []struct {
+		path   string
+		prefix string
+		want   bool
+	}{
+		{"docs/team", "", true},
+		{"docs", "docs", true},
+		{"docs/team", "docs", true},
+		{"docs/team", "docs/", true},
+		{"docs-old/team", "docs", false},
+		{"docs-old/team", "docs/", false},
+		{"guides/docs", "docs", false},
+	}
+
+	for _, test := range tests {
+		if got := hasPathPrefix(test.path, test.prefix); got != test.want {
+			t.Errorf("hasPathPrefix(%q, %q): expected %t, got %t", test.path, test.prefix, test.want, got)
+		}
+	}
+}
@@ -2,19 +2,27 @@ package provider
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
 )
 
 // Ensure WikiJSProvider satisfies various provider interfaces.
@@ -30,15 +38,61 @@ type WikiJSProvider struct {
 
 // WikiJSProviderModel describes the provider data model.
 type WikiJSProviderModel struct {
-	SiteUrl  types.String `tfsdk:"site_url"`
-	Email    types.String `tfsdk:"email"`
-	Password types.String `tfsdk:"password"`
+	SiteUrl            types.String  `tfsdk:"site_url"`
+	Email              types.String  `tfsdk:"email"`
+	Password           types.String  `tfsdk:"password"`
+	ApiToken           types.String  `tfsdk:"api_token"`
+	LoginStrategy      types.String  `tfsdk:"login_strategy"`
+	TotpSecret         types.String  `tfsdk:"totp_secret"`
+	MaxRetries         types.Int64   `tfsdk:"max_retries"`
+	RequestTimeout     types.String  `tfsdk:"request_timeout"`
+	CaCertPEM          types.String  `tfsdk:"ca_cert_pem"`
+	CaCertFile         types.String  `tfsdk:"ca_cert_file"`
+	ClientCert         types.String  `tfsdk:"client_cert"`
+	ClientKey          types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
+	ProxyUrl           types.String  `tfsdk:"proxy_url"`
+	Headers            types.Map     `tfsdk:"headers"`
+	WaitForReady       types.Bool    `tfsdk:"wait_for_ready"`
+	ReadyTimeout       types.String  `tfsdk:"ready_timeout"`
+	MaxConcurrent      types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
+	SessionCache       types.Bool    `tfsdk:"session_cache"`
+	SessionCacheDir    types.String  `tfsdk:"session_cache_dir"`
 }
 
 type WikiJSClient struct {
 	http    *http.Client
 	siteUrl *url.URL
 	graphql graphql.Client
+	session *session
+	// base is the bare connection to wiki.js without authentication,
+	// retries or readiness checks, e.g. for the setup wizard
+	base http.RoundTripper
+
+	versionMu    sync.Mutex
+	version      *version.Version
+	versionKnown bool
+}
+
+// errConfigUnknown fails all requests while the provider configuration is not
+// known yet. Plan time checks that need wiki.js skip themselves on it, they
+// run again during apply.
+var errConfigUnknown = errors.New("the wikijs provider configuration depends on values that are not known yet, wiki.js cannot be queried before they are applied")
+
+// newUnknownConfigClient returns a client failing every request with
+// errConfigUnknown.
+func newUnknownConfigClient() *WikiJSClient {
+	unknown := &errorTransport{err: errConfigUnknown}
+	client := &WikiJSClient{
+		http: &http.Client{
+			Transport: unknown,
+		},
+		base: unknown,
+	}
+	client.graphql = graphql.NewClient("", client.http)
+
+	return client
 }
 
 func (p *WikiJSProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -50,8 +104,9 @@ func (p *WikiJSProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"site_url": schema.StringAttribute{
-				MarkdownDescription: "Wiki.JS Site URL",
-				Required:            true,
+				MarkdownDescription: "Wiki.JS Site URL. The provider only connects and logs in once a resource or data source sends a request, " +
+					"so the URL may reference resources that deploy the wiki in the same configuration.",
+				Required: true,
 			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "Email to login with",
@@ -61,6 +116,108 @@ func (p *WikiJSProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Password to login with",
 				Optional:            true,
 			},
+			"api_token": schema.StringAttribute{
+				MarkdownDescription: "API key to authenticate with instead of `email` and `password`. " +
+					"The key is sent as bearer token with every request. " +
+					"Can also be set with the `TF_PROVIDER_WIKIJS_API_TOKEN` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"login_strategy": schema.StringAttribute{
+				MarkdownDescription: "Key of the authentication strategy to login with, e.g. the key of an LDAP strategy. Defaults to `local`.",
+				Optional:            true,
+			},
+			"totp_secret": schema.StringAttribute{
+				MarkdownDescription: "Base32 encoded TOTP secret of the account. Required to login when two-factor authentication is enabled for the account. " +
+					"Can also be set with the `TF_PROVIDER_WIKIJS_TOTP_SECRET` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How often failed queries and idempotent mutations are retried with exponential backoff. " +
+					"Other mutations are never retried. Defaults to `3`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "How many requests are sent to wiki.js at the same time, across all resources and data sources. " +
+					"Lower it for small instances that cannot handle Terraform's parallelism. Defaults to no limit.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "How many requests are started per second at most, across all resources and data sources. " +
+					"Fractions like `0.5` send one request every two seconds. Defaults to no limit.",
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.001),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout for a single request to wiki.js as Go duration (e.g. `30s`, `2m`). Time spent waiting for `max_concurrent_requests` or `requests_per_second` does not count. Defaults to `1m`.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust in addition to the system CAs, e.g. an internal CA.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with PEM encoded CA certificates to trust in addition to the system CAs.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate presented to an mTLS ingress. Requires `client_key`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the wiki.js TLS certificate. Only use this for testing.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an HTTP proxy to connect through. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Additional HTTP headers sent with every request, e.g. for an identity aware proxy in front of wiki.js.",
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the health check and GraphQL endpoint of wiki.js to answer before the first request. " +
+					"Useful when the wiki is started in the same run.",
+				Optional: true,
+			},
+			"ready_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for wiki.js to become ready as Go duration (e.g. `90s`, `10m`). Defaults to `5m`.",
+				Optional:            true,
+			},
+			"session_cache": schema.BoolAttribute{
+				MarkdownDescription: "Cache the session token of the `email` login on disk. Terraform starts a new provider process for validate, plan and apply, " +
+					"with the cache they reuse a still valid token instead of logging in again. Expired or rejected tokens are replaced by a new login. " +
+					"Can also be enabled with the `TF_PROVIDER_WIKIJS_SESSION_CACHE` environment variable.",
+				Optional: true,
+			},
+			"session_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory of the session cache. The tokens are stored in files only readable by the current user. " +
+					"Defaults to `terraform-provider-wikijs` in the user cache directory, e.g. `~/.cache/terraform-provider-wikijs` on Linux.",
+				Optional: true,
+			},
 		},
 		MarkdownDescription: "The WikiJS provider aims to implement the complete GraphQL API of WikiJS.\n" +
 			"It should be possible to configure any instance of WikiJS via this provider.\n" +
@@ -78,6 +235,16 @@ func (p *WikiJSProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	// The configuration may depend on resources that create the wiki in the
+	// same run. Validate and plan work without a connection, any request
+	// fails until Terraform configures the provider with known values.
+	if !req.Config.Raw.IsFullyKnown() {
+		client := newUnknownConfigClient()
+		resp.DataSourceData = client
+		resp.ResourceData = client
+		return
+	}
+
 	if data.Email.IsNull() {
 		data.Email = types.StringValue(os.Getenv("TF_PROVIDER_WIKIJS_EMAIL"))
 	}
@@ -86,11 +253,88 @@ func (p *WikiJSProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		data.Password = types.StringValue(os.Getenv("TF_PROVIDER_WIKIJS_PASSWORD"))
 	}
 
-	jar, err := cookiejar.New(nil)
+	if data.LoginStrategy.IsNull() {
+		data.LoginStrategy = types.StringValue("local")
+	}
+
+	if data.TotpSecret.IsNull() {
+		data.TotpSecret = types.StringValue(os.Getenv("TF_PROVIDER_WIKIJS_TOTP_SECRET"))
+	}
+
+	if data.ApiToken.IsNull() {
+		data.ApiToken = types.StringValue(os.Getenv("TF_PROVIDER_WIKIJS_API_TOKEN"))
+	}
+
+	if data.SessionCache.IsNull() {
+		enabled, _ := strconv.ParseBool(os.Getenv("TF_PROVIDER_WIKIJS_SESSION_CACHE"))
+		data.SessionCache = types.BoolValue(enabled)
+	}
+
+	if data.ApiToken.ValueString() != "" && (data.Email.ValueString() != "" || data.Password.ValueString() != "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_token"),
+			"Conflicting credentials",
+			"api_token cannot be combined with email and password. Configure either an API key or a login, "+
+				"including the TF_PROVIDER_WIKIJS_API_TOKEN, TF_PROVIDER_WIKIJS_EMAIL and TF_PROVIDER_WIKIJS_PASSWORD environment variables.",
+		)
+		return
+	}
+
+	maxRetries := int64(3)
+	if !data.MaxRetries.IsNull() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	}
+
+	timeout := time.Minute
+	if !data.RequestTimeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(data.RequestTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Could not parse duration", err.Error())
+			return
+		}
+	}
+
+	readyTimeout := 5 * time.Minute
+	if !data.ReadyTimeout.IsNull() {
+		var err error
+		readyTimeout, err = time.ParseDuration(data.ReadyTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ready_timeout"), "Could not parse duration", err.Error())
+			return
+		}
+	}
+
+	headers := map[string]string{}
+	if !data.Headers.IsNull() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	base, err := newBaseTransport(transportOptions{
+		caCertPEM:          data.CaCertPEM.ValueString(),
+		caCertFile:         data.CaCertFile.ValueString(),
+		clientCert:         data.ClientCert.ValueString(),
+		clientKey:          data.ClientKey.ValueString(),
+		insecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+		proxyUrl:           data.ProxyUrl.ValueString(),
+		headers:            headers,
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("could not create cookie jar", err.Error())
+		resp.Diagnostics.AddError("could not configure connection to wiki.js", err.Error())
 		return
 	}
+	base = &timeoutTransport{base: &traceTransport{base: base}, timeout: timeout}
+	base = newThrottleTransport(base, data.MaxConcurrent.ValueInt64(), data.RequestsPerSecond.ValueFloat64())
+
+	var transport http.RoundTripper = &retryTransport{
+		base:       base,
+		maxRetries: int(maxRetries),
+		minBackoff: 500 * time.Millisecond,
+		maxBackoff: 30 * time.Second,
+	}
 
 	siteUrl, err := url.Parse(data.SiteUrl.ValueString())
 	if err != nil {
@@ -98,30 +342,73 @@ func (p *WikiJSProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	if data.WaitForReady.ValueBool() {
+		transport = &readyTransport{
+			base:    transport,
+			probe:   base,
+			siteUrl: siteUrl,
+			timeout: readyTimeout,
+		}
+	}
+
 	client := &WikiJSClient{
 		siteUrl: siteUrl,
-		http: &http.Client{
-			Jar: jar,
-		},
+		http:    &http.Client{},
+		base:    base,
 	}
-	client.graphql = graphql.NewClient(client.siteUrl.JoinPath("/graphql").String(), client.http)
+	endpoint := client.siteUrl.JoinPath("/graphql").String()
+	client.graphql = graphql.NewClient(endpoint, client.http)
 
-	loginResp, err := wikijs.Login(ctx, client.graphql, data.Email.ValueString(), data.Password.ValueString(), "local")
-	if err != nil {
-		resp.Diagnostics.AddError("wiki.js login request failed", err.Error())
+	if data.ApiToken.ValueString() != "" {
+		client.http.Transport = &bearerTransport{
+			base:  transport,
+			token: data.ApiToken.ValueString(),
+		}
+
+		resp.DataSourceData = client
+		resp.ResourceData = client
 		return
 	}
 
-	if !loginResp.Authentication.Login.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("wiki.js Login failed: %s", loginResp.Authentication.Login.ResponseResult.Slug), loginResp.Authentication.Login.ResponseResult.Message)
+	// Without credentials wiki.js is queried as guest
+	if data.Email.ValueString() == "" {
+		client.http.Transport = transport
+
+		resp.DataSourceData = client
+		resp.ResourceData = client
 		return
 	}
 
-	cookie := &http.Cookie{
-		Name:  "jwt",
-		Value: loginResp.Authentication.Login.Jwt,
+	// Logins must not go through the session transport
+	loginClient := graphql.NewClient(endpoint, &http.Client{Transport: transport})
+	creds := loginCredentials{
+		email:      data.Email.ValueString(),
+		password:   data.Password.ValueString(),
+		strategy:   data.LoginStrategy.ValueString(),
+		totpSecret: data.TotpSecret.ValueString(),
+	}
+	// Logging in is deferred until a resource or data source sends a request
+	client.session = &session{
+		login: func(ctx context.Context) (string, error) {
+			return login(ctx, loginClient, creds)
+		},
+	}
+	if data.SessionCache.ValueBool() {
+		dir := data.SessionCacheDir.ValueString()
+		if dir == "" {
+			cacheDir, err := os.UserCacheDir()
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("session_cache_dir"), "Could not determine the user cache directory", err.Error())
+				return
+			}
+			dir = filepath.Join(cacheDir, "terraform-provider-wikijs")
+		}
+		client.session.cache = newSessionCache(dir, client.siteUrl.String(), creds.strategy, creds.email)
+	}
+	client.http.Transport = &sessionTransport{
+		base:    transport,
+		session: client.session,
 	}
-	client.http.Jar.SetCookies(client.siteUrl, []*http.Cookie{cookie})
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -134,12 +421,14 @@ func (p *WikiJSProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewManagedSystemGroupResource,
 		NewLocalizationResource,
 		NewPageResource,
+		NewPageTreeSyncResource,
 		NewApiResource,
 		NewApiKeyResource,
 		NewAuthStrategiesResource,
 		NewThemeConfigResource,
 		NewRenderersResource,
 		NewSearchEnginesResource,
+		NewSetupResource,
 	}
 }
 
@@ -147,6 +436,7 @@ func (p *WikiJSProvider) DataSources(ctx context.Context) []func() datasource.Da
 	return []func() datasource.DataSource{
 		NewSiteConfigDataSource,
 		NewPageDataSource,
+		NewPagesDataSource,
 		NewGroupDataSource,
 		NewGroupsDataSource,
 		NewApiDataSource,
@@ -156,6 +446,7 @@ func (p *WikiJSProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewThemesDataSource,
 		NewRenderersDataSource,
 		NewSearchEnginesDataSource,
+		NewVersionDataSource,
 	}
 }
 
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+// testAccProtoV6ProviderFactories are used to instantiate a provider during
+// acceptance testing. The factory function will be invoked for every Terraform
+// CLI command executed to create a provider server to which the CLI can
+// reattach.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"wikijs": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccServer starts a fake wiki.js for a single acceptance test.
+func testAccServer(t *testing.T, opts ...wikijstest.Option) *wikijstest.Server {
+	t.Helper()
+
+	srv := wikijstest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// testAccProviderConfig returns a provider block that logs into srv as admin.
+func testAccProviderConfig(srv *wikijstest.Server) string {
+	return fmt.Sprintf(`
+provider "wikijs" {
+  site_url = %q
+  email    = %q
+  password = %q
+}
+`, srv.URL, wikijstest.AdminEmail, wikijstest.AdminPassword)
+}
+
+// testClient returns a client logged into srv as admin for tests that call
+// provider functions directly.
+func testClient(srv *wikijstest.Server) *WikiJSClient {
+	endpoint := srv.URL + "/graphql"
+	loginClient := graphql.NewClient(endpoint, http.DefaultClient)
+	creds := loginCredentials{
+		email:    wikijstest.AdminEmail,
+		password: wikijstest.AdminPassword,
+		strategy: "local",
+	}
+
+	client := &WikiJSClient{
+		http: &http.Client{
+			Transport: &sessionTransport{
+				base: http.DefaultTransport,
+				session: &session{
+					login: func(ctx context.Context) (string, error) {
+						return login(ctx, loginClient, creds)
+					},
+				},
+			},
+		},
+	}
+	client.graphql = graphql.NewClient(endpoint, client.http)
+
+	return client
+}
+
+func TestUnknownConfigClient(t *testing.T) {
+	_, err := wikijs.GetSearchEngines(context.Background(), newUnknownConfigClient().graphql, "", "")
+	if !errors.Is(err, errConfigUnknown) {
+		t.Errorf("expected requests to fail with errConfigUnknown, got: %v", err)
+	}
+}
+
+// testApiKey enables the API of srv and returns a full access API key.
+func testApiKey(srv *wikijstest.Server) string {
+	key := "test-api-key"
+	srv.Update(func(store *wikijstest.Store) {
+		store.ApiEnabled = true
+		store.ApiKeys = append(store.ApiKeys, &wikijstest.ApiKey{Id: 100, Name: "terraform", Key: key, FullAccess: true})
+	})
+
+	return key
+}
+
+func TestBearerTransport(t *testing.T) {
+	srv := testAccServer(t)
+	key := testApiKey(srv)
+
+	for token, ok := range map[string]bool{key: true, "wrong": false} {
+		client := graphql.NewClient(srv.URL+"/graphql", &http.Client{Transport: &bearerTransport{base: http.DefaultTransport, token: token}})
+		_, err := wikijs.GetApiState(context.Background(), client)
+		if ok && err != nil {
+			t.Errorf("expected API key to be accepted, got: %s", err)
+		}
+		if !ok && err == nil {
+			t.Errorf("expected API key %q to be rejected", token)
+		}
+	}
+}
+
+func TestAccProviderApiToken(t *testing.T) {
+	srv := testAccServer(t)
+	key := testApiKey(srv)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "wikijs" {
+  site_url  = %q
+  api_token = %q
+}
+
+data "wikijs_version" "test" {}
+`, srv.URL, key),
+				Check: resource.TestCheckResourceAttrSet("data.wikijs_version.test", "current_version"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "wikijs" {
+  site_url  = %q
+  api_token = %q
+  email     = %q
+  password  = %q
+}
+
+data "wikijs_version" "test" {}
+`, srv.URL, key, wikijstest.AdminEmail, wikijstest.AdminPassword),
+				ExpectError: regexp.MustCompile("Conflicting credentials"),
+			},
+		},
+	})
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// readyPollInterval is the delay between two readiness probes.
+const readyPollInterval = 2 * time.Second
+
+// errNotReady fails requests made withoutReadyWait before wiki.js is known to
+// be ready.
+var errNotReady = errors.New("wiki.js is not known to be ready yet")
+
+type skipReadyWaitKey struct{}
+
+// withoutReadyWait marks requests that should fail with errNotReady instead of
+// waiting for wiki.js, e.g. checks at plan time when the wiki may not exist
+// yet.
+func withoutReadyWait(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipReadyWaitKey{}, true)
+}
+
+// readyTransport holds back requests until wiki.js is ready to serve GraphQL
+// requests. The first request starts the probe, later ones wait for it.
+type readyTransport struct {
+	base    http.RoundTripper
+	probe   http.RoundTripper
+	siteUrl *url.URL
+	timeout time.Duration
+
+	mu sync.Mutex
+	// done is closed once the probe finished with err, nil before the first
+	// request
+	done chan struct{}
+	err  error
+}
+
+func (t *readyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// wait blocks until the probe finished or ctx ends. The probe is not bound to
+// the request that started it, so a canceled request only stops its own wait
+// and the probe result is the same for every request.
+func (t *readyTransport) wait(ctx context.Context) error {
+	skip, _ := ctx.Value(skipReadyWaitKey{}).(bool)
+
+	t.mu.Lock()
+	if t.done == nil && !skip {
+		done := make(chan struct{})
+		t.done = done
+		go func() {
+			t.err = waitForReady(context.WithoutCancel(ctx), t.probe, t.siteUrl, t.timeout)
+			close(done)
+		}()
+	}
+	done := t.done
+	t.mu.Unlock()
+
+	if skip {
+		select {
+		case <-done:
+			return t.err
+		default:
+			return errNotReady
+		}
+	}
+
+	select {
+	case <-done:
+		return t.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitForReady polls the health check and GraphQL endpoint of wiki.js until
+// both answer or the timeout is reached.
+func waitForReady(ctx context.Context, transport http.RoundTripper, siteUrl *url.URL, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client := &http.Client{Transport: transport}
+	gql := graphql.NewClient(siteUrl.JoinPath("/graphql").String(), client)
+
+	for {
+		err := probeReady(ctx, client, gql, siteUrl)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wiki.js at %s did not become ready within %s, last probe failed with: %w", siteUrl, timeout, err)
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
+
+func probeReady(ctx context.Context, client *http.Client, gql graphql.Client, siteUrl *url.URL) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, siteUrl.JoinPath("/healthz").String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned %s", resp.Status)
+	}
+
+	// Any GraphQL answer, including permission errors for guests, shows that
+	// the endpoint is serving
+	_, err = wikijs.GetSystemInfo(ctx, gql)
+	var gqlErrors gqlerror.List
+	if err != nil && !errors.As(err, &gqlErrors) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestReadyTransportOutlivesCanceledRequest(t *testing.T) {
+	// The health check hangs until wiki.js "finished starting"
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			<-started
+			fmt.Fprint(w, "ok")
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"data":{"system":{"info":{"currentVersion":"2.5.300"}}}}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	siteUrl, _ := url.Parse(srv.URL)
+	transport := &readyTransport{
+		base:    http.DefaultTransport,
+		probe:   http.DefaultTransport,
+		siteUrl: siteUrl,
+		timeout: time.Minute,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/graphql", nil)
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected request to give up waiting, got: %v", err)
+	}
+
+	close(started)
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/graphql", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected the probe to go on after the first request was canceled, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestReadyTransportWithoutReadyWait(t *testing.T) {
+	siteUrl, _ := url.Parse("http://127.0.0.1:1")
+	transport := &readyTransport{
+		base:    http.DefaultTransport,
+		probe:   http.DefaultTransport,
+		siteUrl: siteUrl,
+		timeout: time.Minute,
+	}
+
+	req, _ := http.NewRequestWithContext(withoutReadyWait(context.Background()), http.MethodGet, siteUrl.String(), nil)
+	if _, err := transport.RoundTrip(req); !errors.Is(err, errNotReady) {
+		t.Errorf("expected request to fail without waiting, got: %v", err)
+	}
+	if transport.done != nil {
+		t.Errorf("expected no probe to be started")
+	}
+}
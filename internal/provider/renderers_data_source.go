@@ -134,7 +134,7 @@ func (d *renderersDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	wresp, err := wikijs.GetRenderers(ctx, d.client.graphql, "", "")
 	if err != nil {
-		resp.Diagnostics.AddError("Get Renderers Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Renderers Request failed", err)
 	}
 
 	type configValue struct {
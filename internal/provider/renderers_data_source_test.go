@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccRenderersDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_renderers" "test" {
+  renderers = []
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_renderers.test", "renderers.#", "3"),
+					resource.TestCheckResourceAttr("data.wikijs_renderers.test", "renderers.0.key", "htmlCore"),
+					resource.TestCheckResourceAttr("data.wikijs_renderers.test", "renderers.1.config.linkify", "true"),
+					resource.TestCheckResourceAttr("data.wikijs_renderers.test", "renderers.2.depends_on", "markdownCore"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.Renderers[2].IsEnabled = false })
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_renderers.test", "renderers.2.is_enabled", "false"),
+			},
+		},
+	})
+}
@@ -14,8 +14,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = &renderersResource{}
-	_ resource.ResourceWithConfigure = &renderersResource{}
+	_ resource.Resource                = &renderersResource{}
+	_ resource.ResourceWithConfigure   = &renderersResource{}
+	_ resource.ResourceWithImportState = &renderersResource{}
 )
 
 func NewRenderersResource() resource.Resource {
@@ -162,12 +163,8 @@ func (r *renderersResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	wresp, err := wikijs.SetRenderers(ctx, r.client.graphql, renderers)
-	if err != nil {
-		resp.Diagnostics.AddError("Set Renderers Request failed", err.Error())
-		return
-	}
-	if !wresp.Rendering.UpdateRenderers.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update renderers: %s", wresp.Rendering.UpdateRenderers.ResponseResult.Slug), wresp.Rendering.UpdateRenderers.ResponseResult.Message)
+	if err := responseError(err, &wresp.Rendering.UpdateRenderers.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update renderers", err)
 		return
 	}
 
@@ -183,7 +180,8 @@ func (r *renderersResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	wresp, err := wikijs.GetRenderers(ctx, r.client.graphql, "", "")
 	if err != nil {
-		resp.Diagnostics.AddError("Get Renderers Request failed.", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Renderers Request failed.", err)
+		return
 	}
 	type configValue struct {
 		Value any `json:"value"`
@@ -238,12 +236,8 @@ func (r *renderersResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	wresp, err := wikijs.SetRenderers(ctx, r.client.graphql, renderers)
-	if err != nil {
-		resp.Diagnostics.AddError("Set Renderers Request failed", err.Error())
-		return
-	}
-	if !wresp.Rendering.UpdateRenderers.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update renderers: %s", wresp.Rendering.UpdateRenderers.ResponseResult.Slug), wresp.Rendering.UpdateRenderers.ResponseResult.Message)
+	if err := responseError(err, &wresp.Rendering.UpdateRenderers.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update renderers", err)
 		return
 	}
 
@@ -253,3 +247,7 @@ func (r *renderersResource) Update(ctx context.Context, req resource.UpdateReque
 func (r *renderersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	resp.Diagnostics.AddWarning("Not changing renderers", "Deleting the wikijs_renderers resource just removes the resource from the terraform state. No wiki.js config is changed.")
 }
+
+func (r *renderersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccRenderersResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRenderersResourceConfig(srv, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_renderers.test", "renderers.#", "3"),
+					resource.TestCheckResourceAttr("wikijs_renderers.test", "renderers.1.config.linkify", "true"),
+					testAccCheckRenderer(srv, "markdownEmoji", true),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_renderers.test",
+				ImportState:                          true,
+				ImportStateId:                        "renderers",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "renderers.0.key",
+			},
+			{
+				Config: testAccRenderersResourceConfig(srv, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_renderers.test", "renderers.2.is_enabled", "false"),
+					testAccCheckRenderer(srv, "markdownEmoji", false),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.Renderers[2].IsEnabled = true })
+				},
+				Config: testAccRenderersResourceConfig(srv, false),
+				Check:  testAccCheckRenderer(srv, "markdownEmoji", false),
+			},
+		},
+		// The renderers are left alone on destroy
+		CheckDestroy: testAccCheckRenderer(srv, "markdownEmoji", false),
+	})
+}
+
+// testAccRenderersResourceConfig lists all renderers of the fake wiki.js.
+func testAccRenderersResourceConfig(srv *wikijstest.Server, emoji bool) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_renderers" "test" {
+  renderers = [
+    {
+      key        = "htmlCore"
+      is_enabled = true
+      config = {
+        absoluteLinks            = false
+        openExternalLinkNewTab   = true
+        relAttributeExternalLink = "noreferrer"
+      }
+    },
+    {
+      key        = "markdownCore"
+      is_enabled = true
+      config = {
+        allowHTML   = true
+        linkify     = true
+        typographer = false
+      }
+    },
+    {
+      key        = "markdownEmoji"
+      is_enabled = %t
+      config     = {}
+    },
+  ]
+}
+`, emoji)
+}
+
+func testAccCheckRenderer(srv *wikijstest.Server, key string, enabled bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var found, actual bool
+		srv.Update(func(store *wikijstest.Store) {
+			for _, r := range store.Renderers {
+				if r.Key == key {
+					found, actual = true, r.IsEnabled
+				}
+			}
+		})
+		if !found {
+			return fmt.Errorf("renderer %s does not exist", key)
+		}
+		if actual != enabled {
+			return fmt.Errorf("expected renderer %s enabled to be %t, got %t", key, enabled, actual)
+		}
+		return nil
+	}
+}
@@ -127,7 +127,7 @@ func (d *searchEnginesDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	wresp, err := wikijs.GetSearchEngines(ctx, d.client.graphql, "", "")
 	if err != nil {
-		resp.Diagnostics.AddError("Get Search Engines Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Search Engines Request failed", err)
 	}
 
 	type configValue struct {
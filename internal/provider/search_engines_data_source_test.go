@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccSearchEnginesDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_search_engines" "test" {
+  search_engines = []
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_search_engines.test", "search_engines.#", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_search_engines.test", "search_engines.0.key", "db"),
+					resource.TestCheckResourceAttr("data.wikijs_search_engines.test", "search_engines.0.is_enabled", "true"),
+					resource.TestCheckResourceAttr("data.wikijs_search_engines.test", "search_engines.1.config.dictLanguage", "english"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.SearchEngines[1].Config["dictLanguage"] = "german" })
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_search_engines.test", "search_engines.1.config.dictLanguage", "german"),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -21,6 +22,7 @@ var (
 	_ resource.ResourceWithConfigure      = &searchEnginesResource{}
 	_ resource.ResourceWithModifyPlan     = &searchEnginesResource{}
 	_ resource.ResourceWithValidateConfig = &searchEnginesResource{}
+	_ resource.ResourceWithImportState    = &searchEnginesResource{}
 )
 
 func NewSearchEnginesResource() resource.Resource {
@@ -119,6 +121,10 @@ func (r *searchEnginesResource) Configure(_ context.Context, req resource.Config
 }
 
 func (r *searchEnginesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
 	var data, plan, state *searchEnginesResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -136,9 +142,15 @@ func (r *searchEnginesResource) ModifyPlan(ctx context.Context, req resource.Mod
 		)
 	}
 
-	wresp, err := wikijs.GetSearchEngines(ctx, r.client.graphql, "", "")
+	// A wiki that is not up yet, e.g. because it is created in the same
+	// run, is only checked during apply
+	wresp, err := wikijs.GetSearchEngines(withoutReadyWait(ctx), r.client.graphql, "", "")
+	if errors.Is(err, errConfigUnknown) || errors.Is(err, errNotReady) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Get Search Engines Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Search Engines Request failed", err)
+		return
 	}
 	isAvailable := map[string]bool{}
 	for _, ws := range wresp.Search.SearchEngines {
@@ -245,20 +257,13 @@ func (r *searchEnginesResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	wresp, err := wikijs.SetSearchEngines(ctx, r.client.graphql, searchEngines)
-	if err != nil {
-		resp.Diagnostics.AddError("Set Search Engines Request failed", err.Error())
-		return
-	}
-	if !wresp.Search.UpdateSearchEngines.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update search engines: %s", wresp.Search.UpdateSearchEngines.ResponseResult.Slug), wresp.Search.UpdateSearchEngines.ResponseResult.Message)
+	if err := responseError(err, &wresp.Search.UpdateSearchEngines.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update search engines", err)
 		return
 	}
 	wresp2, err := wikijs.RebuildSearchIndex(ctx, r.client.graphql)
-	if err != nil {
-		resp.Diagnostics.AddError("Rebuild Search Index Request failed", err.Error())
-	}
-	if !wresp2.Search.RebuildIndex.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not rebuild search index: %s", wresp2.Search.RebuildIndex.ResponseResult.Slug), wresp2.Search.RebuildIndex.ResponseResult.Message)
+	if err := responseError(err, &wresp2.Search.RebuildIndex.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not rebuild search index", err)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
@@ -273,7 +278,8 @@ func (r *searchEnginesResource) Read(ctx context.Context, req resource.ReadReque
 
 	wresp, err := wikijs.GetSearchEngines(ctx, r.client.graphql, "", "")
 	if err != nil {
-		resp.Diagnostics.AddError("Get Search Engines Request failed.", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Search Engines Request failed.", err)
+		return
 	}
 	type configValue struct {
 		Value any `json:"value"`
@@ -328,20 +334,13 @@ func (r *searchEnginesResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	wresp, err := wikijs.SetSearchEngines(ctx, r.client.graphql, searchEngines)
-	if err != nil {
-		resp.Diagnostics.AddError("Set Search Engines Request failed", err.Error())
-		return
-	}
-	if !wresp.Search.UpdateSearchEngines.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not update search engines: %s", wresp.Search.UpdateSearchEngines.ResponseResult.Slug), wresp.Search.UpdateSearchEngines.ResponseResult.Message)
+	if err := responseError(err, &wresp.Search.UpdateSearchEngines.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not update search engines", err)
 		return
 	}
 	wresp2, err := wikijs.RebuildSearchIndex(ctx, r.client.graphql)
-	if err != nil {
-		resp.Diagnostics.AddError("Rebuild Search Index Request failed", err.Error())
-	}
-	if !wresp2.Search.RebuildIndex.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not rebuild search index: %s", wresp2.Search.RebuildIndex.ResponseResult.Slug), wresp2.Search.RebuildIndex.ResponseResult.Message)
+	if err := responseError(err, &wresp2.Search.RebuildIndex.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not rebuild search index", err)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
@@ -350,3 +349,7 @@ func (r *searchEnginesResource) Update(ctx context.Context, req resource.UpdateR
 func (r *searchEnginesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	resp.Diagnostics.AddWarning("Not changing search engines", "Deleting the wikijs_search_engines resource just removes the resource from the terraform state. No wiki.js config is changed.")
 }
+
+func (r *searchEnginesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccSearchEnginesResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSearchEnginesResourceConfig(srv, "db"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_search_engines.test", "search_engines.#", "2"),
+					resource.TestCheckResourceAttr("wikijs_search_engines.test", "search_engines.1.config.dictLanguage", "english"),
+					testAccCheckSearchEngine(srv, "db"),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_search_engines.test",
+				ImportState:                          true,
+				ImportStateId:                        "search_engines",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "search_engines.0.key",
+			},
+			{
+				Config: testAccSearchEnginesResourceConfig(srv, "postgres"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_search_engines.test", "search_engines.1.is_enabled", "true"),
+					testAccCheckSearchEngine(srv, "postgres"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.SearchEngines[0].IsEnabled = true
+						store.SearchEngines[1].IsEnabled = false
+					})
+				},
+				Config: testAccSearchEnginesResourceConfig(srv, "postgres"),
+				Check:  testAccCheckSearchEngine(srv, "postgres"),
+			},
+		},
+		// The search engines are left alone on destroy
+		CheckDestroy: testAccCheckSearchEngine(srv, "postgres"),
+	})
+}
+
+// testAccSearchEnginesResourceConfig lists all search engines of the fake
+// wiki.js with only enabled switched on.
+func testAccSearchEnginesResourceConfig(srv *wikijstest.Server, enabled string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_search_engines" "test" {
+  search_engines = [
+    {
+      key        = "db"
+      is_enabled = %t
+      config     = {}
+    },
+    {
+      key        = "postgres"
+      is_enabled = %t
+      config = {
+        dictLanguage = "english"
+      }
+    },
+  ]
+}
+`, enabled == "db", enabled == "postgres")
+}
+
+// testAccCheckSearchEngine checks that only the given search engine is enabled.
+func testAccCheckSearchEngine(srv *wikijstest.Server, key string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var enabled []string
+		srv.Update(func(store *wikijstest.Store) {
+			for _, e := range store.SearchEngines {
+				if e.IsEnabled {
+					enabled = append(enabled, e.Key)
+				}
+			}
+		})
+		if len(enabled) != 1 || enabled[0] != key {
+			return fmt.Errorf("expected only search engine %s to be enabled, got %v", key, enabled)
+		}
+		return nil
+	}
+}
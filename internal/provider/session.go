@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sessionRenewMargin is how long before its expiry a JWT is replaced by a new
+// login instead of being sent to wiki.js.
+const sessionRenewMargin = time.Minute
+
+// session manages the JWT of a logged in wiki.js account. The first login
+// happens with the first request. Tokens renewed by wiki.js are picked up from
+// responses, expired tokens trigger a new login. With a cache, the first
+// request reuses a token another provider process logged in with.
+type session struct {
+	mu        sync.Mutex
+	jwt       string
+	expiresAt time.Time
+	login     func(ctx context.Context) (string, error)
+	cache     *sessionCache
+	loaded    bool
+
+	// cached is set while the token is one loaded from the cache that
+	// wiki.js has not accepted yet.
+	cached bool
+}
+
+// token returns a JWT that is valid for at least sessionRenewMargin and logs
+// in again if necessary.
+func (s *session) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cache != nil && !s.loaded {
+		s.loaded = true
+		jwt, err := s.cache.load()
+		if err != nil {
+			tflog.Warn(ctx, "could not read wiki.js session cache", map[string]interface{}{"error": err.Error()})
+		}
+		if jwt != "" {
+			tflog.Debug(ctx, "reusing cached wiki.js session")
+			s.set(jwt)
+			s.cached = true
+		}
+	}
+
+	if s.jwt != "" && (s.expiresAt.IsZero() || time.Until(s.expiresAt) > sessionRenewMargin) {
+		return s.jwt, nil
+	}
+
+	return s.relogin(ctx)
+}
+
+// renew logs in again unless another request already replaced the stale token.
+func (s *session) renew(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.jwt != stale {
+		return s.jwt, nil
+	}
+
+	return s.relogin(ctx)
+}
+
+// mayBeRejected reports whether wiki.js answering Forbidden to jwt can mean
+// it no longer accepts the session, instead of the account lacking a
+// permission. That is the case if the token expired, or if it was loaded
+// from the cache and wiki.js has not accepted it yet.
+func (s *session) mayBeRejected(jwt string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if jwt != s.jwt {
+		return false
+	}
+
+	return s.cached || (!s.expiresAt.IsZero() && !time.Now().Before(s.expiresAt))
+}
+
+// accepted records that wiki.js answered a request made with jwt.
+func (s *session) accepted(jwt string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if jwt == s.jwt {
+		s.cached = false
+	}
+}
+
+// update stores a token renewed by wiki.js.
+func (s *session) update(ctx context.Context, jwt string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if jwt == s.jwt {
+		return
+	}
+	s.set(jwt)
+	s.store(ctx)
+}
+
+func (s *session) relogin(ctx context.Context) (string, error) {
+	jwt, err := s.login(ctx)
+	if err != nil {
+		return "", &wikijsError{kind: errorKindAuth, err: fmt.Errorf("wiki.js login failed: %w", err)}
+	}
+	s.set(jwt)
+	s.store(ctx)
+
+	return jwt, nil
+}
+
+func (s *session) set(jwt string) {
+	s.jwt = jwt
+	s.expiresAt = jwtExpiration(jwt)
+	s.cached = false
+}
+
+// store writes the current token to the cache. A failing cache only costs
+// logins, so errors are logged instead of failing the request.
+func (s *session) store(ctx context.Context) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.store(s.jwt); err != nil {
+		tflog.Warn(ctx, "could not write wiki.js session cache", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+// jwtExpiration returns the exp claim of a JWT without verifying it. The zero
+// time is returned if the token has no readable expiration.
+func jwtExpiration(jwt string) time.Time {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
+
+// sessionTransport authenticates requests with the session JWT. It retries a
+// request once with a fresh login when wiki.js no longer accepts the session.
+// wiki.js answers Forbidden both for expired sessions and for missing
+// permissions, so Forbidden only leads to a login if the session may have
+// been rejected, which is at most once per token.
+type sessionTransport struct {
+	base    http.RoundTripper
+	session *session
+}
+
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := t.session.token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.roundTrip(req, jwt)
+	if err != nil || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		if !t.session.mayBeRejected(jwt) {
+			return resp, nil
+		}
+		if !forbidden(resp) {
+			t.session.accepted(jwt)
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+
+	jwt, err = t.session.renew(req.Context(), jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.roundTrip(req, jwt)
+}
+
+func (t *sessionTransport) roundTrip(req *http.Request, jwt string) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	out := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		out.Body = body
+	}
+	out.AddCookie(&http.Cookie{Name: "jwt", Value: jwt})
+
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	// wiki.js sends renewed tokens as header for JSON requests and as cookie otherwise
+	if renewed := resp.Header.Get("new-jwt"); renewed != "" {
+		t.session.update(req.Context(), renewed)
+	} else {
+		for _, c := range resp.Cookies() {
+			if c.Name == "jwt" && c.Value != "" {
+				t.session.update(req.Context(), c.Value)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// forbidden reports whether wiki.js answered the request with a Forbidden
+// GraphQL error. The response body is restored for the caller.
+func forbidden(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	var result struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false
+	}
+	for _, e := range result.Errors {
+		if e.Message == "Forbidden" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// sessionCache stores the session JWT of one account on disk, so the provider
+// processes Terraform starts for validate, plan and apply share a login.
+type sessionCache struct {
+	path string
+}
+
+// cachedSession is the file format of the session cache.
+type cachedSession struct {
+	Jwt string `json:"jwt"`
+}
+
+// newSessionCache returns the cache for the account on the wiki at siteUrl.
+// The file name is derived from a hash, so it does not reveal the account.
+func newSessionCache(dir string, siteUrl string, strategy string, email string) *sessionCache {
+	key := sha256.Sum256([]byte(siteUrl + "\x00" + strategy + "\x00" + email))
+
+	return &sessionCache{
+		path: filepath.Join(dir, hex.EncodeToString(key[:])+".json"),
+	}
+}
+
+// load returns the cached JWT if it is still valid for at least
+// sessionRenewMargin. An empty string is returned otherwise.
+func (c *sessionCache) load() (string, error) {
+	content, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var cached cachedSession
+	if err := json.Unmarshal(content, &cached); err != nil {
+		return "", fmt.Errorf("could not parse session cache %s: %w", c.path, err)
+	}
+
+	// Tokens without a readable expiration could be valid forever
+	expiresAt := jwtExpiration(cached.Jwt)
+	if expiresAt.IsZero() || time.Until(expiresAt) <= sessionRenewMargin {
+		return "", nil
+	}
+
+	return cached.Jwt, nil
+}
+
+// store replaces the cached JWT. The file is only readable by the current
+// user and replaced atomically, so parallel provider processes never read a
+// partially written token.
+func (c *sessionCache) store(jwt string) error {
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(cachedSession{Jwt: jwt})
+	if err != nil {
+		return err
+	}
+
+	// CreateTemp creates the file with mode 0600
+	tmp, err := os.CreateTemp(dir, ".session-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// testJWT returns an unsigned JWT expiring at exp.
+func testJWT(exp time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+
+	return header + "." + payload + ".signature"
+}
+
+func TestSessionCacheStore(t *testing.T) {
+	cache := newSessionCache(t.TempDir()+"/cache", "https://wiki.example.com", "local", "admin@example.com")
+	jwt := testJWT(time.Now().Add(time.Hour))
+
+	if err := cache.store(jwt); err != nil {
+		t.Fatalf("could not store session: %s", err)
+	}
+
+	info, err := os.Stat(cache.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("expected cache file mode 0600, got %o", mode)
+	}
+
+	got, err := cache.load()
+	if err != nil {
+		t.Fatalf("could not load session: %s", err)
+	}
+	if got != jwt {
+		t.Errorf("expected cached token %q, got %q", jwt, got)
+	}
+}
+
+func TestSessionCacheKeys(t *testing.T) {
+	dir := t.TempDir()
+	cache := newSessionCache(dir, "https://wiki.example.com", "local", "admin@example.com")
+
+	for _, other := range []*sessionCache{
+		newSessionCache(dir, "https://other.example.com", "local", "admin@example.com"),
+		newSessionCache(dir, "https://wiki.example.com", "ldap", "admin@example.com"),
+		newSessionCache(dir, "https://wiki.example.com", "local", "editor@example.com"),
+	} {
+		if other.path == cache.path {
+			t.Errorf("expected different accounts to use different cache files, both use %s", cache.path)
+		}
+	}
+}
+
+func TestSessionCacheIgnoresExpiredTokens(t *testing.T) {
+	cache := newSessionCache(t.TempDir(), "https://wiki.example.com", "local", "admin@example.com")
+
+	for name, jwt := range map[string]string{
+		"expired":       testJWT(time.Now().Add(-time.Hour)),
+		"expiring soon": testJWT(time.Now().Add(sessionRenewMargin / 2)),
+		"no expiration": "not-a-jwt",
+	} {
+		if err := cache.store(jwt); err != nil {
+			t.Fatalf("could not store session: %s", err)
+		}
+		got, err := cache.load()
+		if err != nil {
+			t.Fatalf("could not load session: %s", err)
+		}
+		if got != "" {
+			t.Errorf("expected %s token to be ignored, got %q", name, got)
+		}
+	}
+}
+
+func TestSessionReusesCachedToken(t *testing.T) {
+	cache := newSessionCache(t.TempDir(), "https://wiki.example.com", "local", "admin@example.com")
+	cached := testJWT(time.Now().Add(time.Hour))
+	if err := cache.store(cached); err != nil {
+		t.Fatalf("could not store session: %s", err)
+	}
+
+	fresh := testJWT(time.Now().Add(2 * time.Hour))
+	logins := 0
+	s := &session{
+		login: func(ctx context.Context) (string, error) {
+			logins++
+			return fresh, nil
+		},
+		cache: cache,
+	}
+
+	jwt, err := s.token(context.Background())
+	if err != nil {
+		t.Fatalf("expected cached token, got: %s", err)
+	}
+	if jwt != cached || logins != 0 {
+		t.Errorf("expected cached token without login, got %q after %d logins", jwt, logins)
+	}
+
+	// wiki.js rejected the cached token
+	jwt, err = s.renew(context.Background(), cached)
+	if err != nil {
+		t.Fatalf("expected login to succeed, got: %s", err)
+	}
+	if jwt != fresh || logins != 1 {
+		t.Errorf("expected token of a new login, got %q after %d logins", jwt, logins)
+	}
+
+	got, err := cache.load()
+	if err != nil {
+		t.Fatalf("could not load session: %s", err)
+	}
+	if got != fresh {
+		t.Errorf("expected new token in cache, got %q", got)
+	}
+}
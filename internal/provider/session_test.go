@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestSessionRelogin(t *testing.T) {
+	srv := testAccServer(t, wikijstest.WithSessionLifetime(time.Second))
+	endpoint := srv.URL + "/graphql"
+	loginClient := graphql.NewClient(endpoint, http.DefaultClient)
+	creds := loginCredentials{
+		email:    wikijstest.AdminEmail,
+		password: wikijstest.AdminPassword,
+		strategy: "local",
+	}
+
+	logins := 0
+	s := &session{
+		login: func(ctx context.Context) (string, error) {
+			logins++
+			return login(ctx, loginClient, creds)
+		},
+	}
+	client := graphql.NewClient(endpoint, &http.Client{Transport: &sessionTransport{base: http.DefaultTransport, session: s}})
+	ctx := context.Background()
+
+	if _, err := wikijs.GetApiState(ctx, client); err != nil {
+		t.Fatalf("expected first request to log in, got: %s", err)
+	}
+	if logins != 1 {
+		t.Fatalf("expected 1 login, got %d", logins)
+	}
+
+	// The token expires within sessionRenewMargin and is replaced before the request
+	if _, err := wikijs.GetApiState(ctx, client); err != nil {
+		t.Fatalf("expected request with renewed token to succeed, got: %s", err)
+	}
+	if logins != 2 {
+		t.Fatalf("expected a new login before the request, got %d logins", logins)
+	}
+
+	// Forbidden for a token that has not expired means the account lacks a
+	// permission and does not lead to another login
+	s.expiresAt = time.Now().Add(time.Hour)
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := wikijs.GetApiState(ctx, client); err == nil {
+		t.Fatal("expected Forbidden to be passed through")
+	}
+	if logins != 2 {
+		t.Fatalf("expected no login for Forbidden, got %d logins", logins)
+	}
+
+	// A cached token wiki.js does not accept is replaced once
+	s.cached = true
+	if _, err := wikijs.GetApiState(ctx, client); err != nil {
+		t.Fatalf("expected request to log in again, got: %s", err)
+	}
+	if logins != 3 {
+		t.Errorf("expected a new login for the rejected cached token, got %d logins", logins)
+	}
+}
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &setupResource{}
+	_ resource.ResourceWithConfigure = &setupResource{}
+)
+
+// NewSetupResource is a helper function to simplify the provider implementation.
+func NewSetupResource() resource.Resource {
+	return &setupResource{}
+}
+
+// setupResource is the resource implementation.
+type setupResource struct {
+	client *WikiJSClient
+}
+
+type setupResourceModel struct {
+	AdminEmail    types.String `tfsdk:"admin_email"`
+	AdminPassword types.String `tfsdk:"admin_password"`
+	SiteUrl       types.String `tfsdk:"site_url"`
+	Telemetry     types.Bool   `tfsdk:"telemetry"`
+	ReadyTimeout  types.String `tfsdk:"ready_timeout"`
+	Completed     types.Bool   `tfsdk:"completed"`
+}
+
+// Metadata returns the resource type name.
+func (r *setupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setup"
+}
+
+// Schema defines the schema for the resource.
+func (r *setupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"admin_email": schema.StringAttribute{
+				Required:    true,
+				Description: "Email of the administrator account created by the setup",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"admin_password": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the administrator account created by the setup (at least 6 characters)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(6),
+				},
+			},
+			"site_url": schema.StringAttribute{
+				Required:    true,
+				Description: "Public URL of the wiki stored as site host",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"telemetry": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether to send anonymous telemetry to the wiki.js project",
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"ready_timeout": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "How long to wait for wiki.js to answer before the setup and to restart after it as Go duration",
+				Default:     stringdefault.StaticString("5m"),
+			},
+			"completed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether this resource completed the setup. False if the wiki was already set up before",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		MarkdownDescription: "The `wikijs_setup` resource completes the first-run setup wizard of a fresh wiki.js installation.\n" +
+			"It creates the administrator account, sets the site URL and telemetry choice and waits for wiki.js to restart.\n" +
+			"If the wiki is already set up, nothing is changed.\n" +
+			"\n" +
+			"Let other resources depend on this resource, the provider logs in with its credentials after the setup is completed.\n" +
+			"All attributes only apply to the initial setup, destroying the resource does not change the wiki.",
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (d *setupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*WikiJSClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *WikiJSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *setupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Read Terraform plan data into the model
+	var data *setupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := time.ParseDuration(data.ReadyTimeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ready_timeout"), "Could not parse duration", err.Error())
+		return
+	}
+
+	if r.client.siteUrl == nil {
+		resp.Diagnostics.AddError("Wiki.js site url unknown", "The provider site_url must be known to run the setup")
+		return
+	}
+
+	installed, err := r.waitForSetupState(ctx, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not detect Wiki.js setup state", err.Error())
+		return
+	}
+
+	data.Completed = types.BoolValue(!installed)
+	if installed {
+		resp.Diagnostics.AddWarning("Wiki.js is already set up", "The setup wizard was completed before, the wikijs_setup attributes were not applied.")
+		resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+		return
+	}
+
+	if err := r.finalize(ctx, data); err != nil {
+		resp.Diagnostics.AddError("Wiki.js setup failed", err.Error())
+		return
+	}
+
+	// wiki.js restarts into normal operation after finalizing the setup
+	if err := waitForReady(ctx, r.client.base, r.client.siteUrl, timeout); err != nil {
+		resp.Diagnostics.AddError("Wiki.js did not start after setup", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *setupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The setup can not be read back, keep the state as it is
+	var data *setupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *setupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var data *setupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *setupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning("Wiki.js setup kept", "Deleting the wikijs_setup terraform resource does not reset the wiki.js installation.")
+}
+
+// waitForSetupState checks whether wiki.js finished the setup until it gives
+// a definite answer or the timeout is reached, e.g. while a proxy in front of
+// a starting wiki.js answers with 502.
+func (r *setupResource) waitForSetupState(ctx context.Context, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		installed, err := r.installed(ctx)
+		if err == nil {
+			return installed, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, fmt.Errorf("wiki.js at %s did not answer within %s, last check failed with: %w", r.client.siteUrl, timeout, err)
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
+
+// installed reports whether wiki.js finished the setup. During the setup
+// wiki.js only serves the wizard and answers 404 on the GraphQL endpoint. Any
+// other answer than that or a JSON response is an error, as it does not tell
+// which state wiki.js is in.
+func (r *setupResource) installed(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.client.siteUrl.JoinPath("/graphql").String(), strings.NewReader(`{"query":"{ __typename }"}`))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.base.RoundTrip(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK && strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json"):
+		return true, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	}
+
+	return false, fmt.Errorf("GraphQL endpoint returned %s", resp.Status)
+}
+
+// finalize submits the setup wizard.
+func (r *setupResource) finalize(ctx context.Context, data *setupResourceModel) error {
+	body, err := json.Marshal(map[string]any{
+		"adminEmail":           data.AdminEmail.ValueString(),
+		"adminPassword":        data.AdminPassword.ValueString(),
+		"adminPasswordConfirm": data.AdminPassword.ValueString(),
+		"siteUrl":              data.SiteUrl.ValueString(),
+		"telemetry":            data.Telemetry.ValueBool(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.client.siteUrl.JoinPath("/finalize").String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.base.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("setup returned %s", resp.Status)
+	}
+
+	var result struct {
+		Ok    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("could not decode setup response: %w", err)
+	}
+	if !result.Ok {
+		return errors.New(result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+// The setup can neither be read back nor undone, so there are no import,
+// drift or destroy checks.
+func TestAccSetupResource(t *testing.T) {
+	srv := testAccServer(t, wikijstest.WithSetupPending())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSetupResourceConfig(srv, "1m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_setup.test", "completed", "true"),
+					resource.TestCheckResourceAttr("wikijs_setup.test", "telemetry", "false"),
+					// The provider logs in once the setup is completed
+					resource.TestCheckResourceAttrSet("wikijs_page.test", "id"),
+					func(*terraform.State) error {
+						var installed bool
+						srv.Update(func(store *wikijstest.Store) { installed = store.Installed })
+						if !installed {
+							return fmt.Errorf("expected wiki.js to be installed")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSetupResourceConfig(srv, "2m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_setup.test", "ready_timeout", "2m"),
+					resource.TestCheckResourceAttr("wikijs_setup.test", "completed", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSetupResourceInstalled(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSetupResourceConfig(srv, "1m"),
+				Check:  resource.TestCheckResourceAttr("wikijs_setup.test", "completed", "false"),
+			},
+		},
+	})
+}
+
+func testAccSetupResourceConfig(srv *wikijstest.Server, readyTimeout string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_setup" "test" {
+  admin_email    = %q
+  admin_password = %q
+  site_url       = "https://wiki.example.com"
+  ready_timeout  = %q
+}
+
+resource "wikijs_page" "test" {
+  path        = "home"
+  locale      = "en"
+  title       = "Home"
+  description = ""
+  content     = "Welcome"
+
+  depends_on = [wikijs_setup.test]
+}
+`, wikijstest.AdminEmail, wikijstest.AdminPassword, readyTimeout)
+}
+
+func TestSetupResourceInstalled(t *testing.T) {
+	for status, expected := range map[int]string{
+		http.StatusOK:                 "installed",
+		http.StatusNotFound:           "setup pending",
+		http.StatusBadGateway:         "error",
+		http.StatusServiceUnavailable: "error",
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"data":{"__typename":"Query"}}`)
+		}))
+		siteUrl, _ := url.Parse(srv.URL)
+		r := &setupResource{client: &WikiJSClient{siteUrl: siteUrl, base: http.DefaultTransport}}
+
+		installed, err := r.installed(context.Background())
+		got := "setup pending"
+		switch {
+		case err != nil:
+			got = "error"
+		case installed:
+			got = "installed"
+		}
+		if got != expected {
+			t.Errorf("expected %d to mean %s, got %s", status, expected, got)
+		}
+		srv.Close()
+	}
+}
+
+func TestSetupResourceWaitsForSetupState(t *testing.T) {
+	// A proxy answers until wiki.js started in setup mode
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	siteUrl, _ := url.Parse(srv.URL)
+	r := &setupResource{client: &WikiJSClient{siteUrl: siteUrl, base: http.DefaultTransport}}
+
+	installed, err := r.waitForSetupState(context.Background(), time.Minute)
+	if err != nil {
+		t.Fatalf("expected setup state after a retry, got: %s", err)
+	}
+	if installed || requests.Load() != 2 {
+		t.Errorf("expected setup pending after 2 requests, got installed %t after %d requests", installed, requests.Load())
+	}
+}
@@ -260,9 +260,14 @@ func (d *siteConfigDataSource) Configure(_ context.Context, req datasource.Confi
 func (d *siteConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state siteConfigDataSourceModel
 
+	resp.Diagnostics.Append(d.client.checkCapability(ctx, capabilityEditShortcuts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wresp, err := wikijs.GetSiteConfig(ctx, d.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("could not query wiki.js graphql api", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "could not query wiki.js graphql api", err)
 		return
 	}
 
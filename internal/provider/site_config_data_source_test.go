@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccSiteConfigDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_site_config" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_site_config.test", "title", "Wiki.js"),
+					resource.TestCheckResourceAttr("data.wikijs_site_config.test", "robots.#", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_site_config.test", "upload_max_files", "10"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.SiteConfig["company"] = "ACME" })
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_site_config.test", "company", "ACME"),
+			},
+		},
+	})
+}
@@ -17,8 +17,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &siteConfigResource{}
-	_ resource.ResourceWithConfigure = &siteConfigResource{}
+	_ resource.Resource                = &siteConfigResource{}
+	_ resource.ResourceWithConfigure   = &siteConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &siteConfigResource{}
+	_ resource.ResourceWithImportState = &siteConfigResource{}
 )
 
 // NewSiteConfigResource is a helper function to simplify the provider implementation.
@@ -342,6 +344,17 @@ func (d *siteConfigResource) Configure(_ context.Context, req resource.Configure
 	d.client = client
 }
 
+// ModifyPlan rejects the plan if the connected wiki.js does not know all
+// settings. It does not wait for wiki.js to become ready, which may only
+// happen during apply. Create and Update check again before they apply.
+func (r *siteConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.client.checkCapability(withoutReadyWait(ctx), capabilityEditShortcuts)...)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *siteConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Read Terraform plan data into the model
@@ -351,16 +364,17 @@ func (r *siteConfigResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(r.client.checkCapability(ctx, capabilityEditShortcuts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var robots []string
 	resp.Diagnostics.Append(data.Robots.ElementsAs(ctx, &robots, false)...)
 
 	wresp, err := wikijs.UpdateSiteConfig(ctx, r.client.graphql, data.Host.ValueString(), data.Title.ValueString(), data.Description.ValueString(), robots, data.AnalyticsService.ValueString(), data.AnalyticsId.ValueString(), data.Company.ValueString(), data.ContentLicense.ValueString(), data.FooterOverride.ValueString(), data.LogoUrl.ValueString(), data.PageExtensions.ValueString(), data.AuthAutoLogin.ValueBool(), data.AuthEnforce2FA.ValueBool(), data.AuthHideLocal.ValueBool(), data.AuthLoginBgUrl.ValueString(), data.AuthJwtAudience.ValueString(), data.AuthJwtExpiration.ValueString(), data.AuthJwtRenewablePeriod.ValueString(), data.EditFab.ValueBool(), data.EditMenuBar.ValueBool(), data.EditMenuBtn.ValueBool(), data.EditMenuExternalBtn.ValueBool(), data.EditMenuExternalName.ValueString(), data.EditMenuExternalIcon.ValueString(), data.EditMenuExternalUrl.ValueString(), data.FeaturePageRatings.ValueBool(), data.FeaturePageComments.ValueBool(), data.FeaturePersonalWikis.ValueBool(), data.SecurityOpenRedirect.ValueBool(), data.SecurityIframe.ValueBool(), data.SecurityReferrerPolicy.ValueBool(), data.SecurityTrustProxy.ValueBool(), data.SecuritySRI.ValueBool(), data.SecurityHSTS.ValueBool(), int(data.SecurityHSTSDuration.ValueInt64()), data.SecurityCSP.ValueBool(), data.SecurityCSPDirectives.ValueString(), int(data.UploadMaxFileSize.ValueInt64()), int(data.UploadMaxFiles.ValueInt64()), data.UploadScanSVG.ValueBool(), data.UploadForceDownload.ValueBool())
-	if err != nil {
-		resp.Diagnostics.AddError("could not update wiki.js site config", err.Error())
-		return
-	}
-	if !wresp.Site.UpdateConfig.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("wiki.js refused site config update: %s", wresp.Site.UpdateConfig.ResponseResult.Slug), wresp.Site.UpdateConfig.ResponseResult.Message)
+	if err := responseError(err, &wresp.Site.UpdateConfig.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "wiki.js refused site config update", err)
 		return
 	}
 
@@ -376,9 +390,14 @@ func (r *siteConfigResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.client.checkCapability(ctx, capabilityEditShortcuts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wresp, err := wikijs.GetSiteConfig(ctx, r.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("could not query wiki.js graphql api", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "could not query wiki.js graphql api", err)
 		return
 	}
 
@@ -440,16 +459,17 @@ func (r *siteConfigResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(r.client.checkCapability(ctx, capabilityEditShortcuts)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var robots []string
 	resp.Diagnostics.Append(data.Robots.ElementsAs(ctx, &robots, false)...)
 
 	wresp, err := wikijs.UpdateSiteConfig(ctx, r.client.graphql, data.Host.ValueString(), data.Title.ValueString(), data.Description.ValueString(), robots, data.AnalyticsService.ValueString(), data.AnalyticsId.ValueString(), data.Company.ValueString(), data.ContentLicense.ValueString(), data.FooterOverride.ValueString(), data.LogoUrl.ValueString(), data.PageExtensions.ValueString(), data.AuthAutoLogin.ValueBool(), data.AuthEnforce2FA.ValueBool(), data.AuthHideLocal.ValueBool(), data.AuthLoginBgUrl.ValueString(), data.AuthJwtAudience.ValueString(), data.AuthJwtExpiration.ValueString(), data.AuthJwtRenewablePeriod.ValueString(), data.EditFab.ValueBool(), data.EditMenuBar.ValueBool(), data.EditMenuBtn.ValueBool(), data.EditMenuExternalBtn.ValueBool(), data.EditMenuExternalName.ValueString(), data.EditMenuExternalIcon.ValueString(), data.EditMenuExternalUrl.ValueString(), data.FeaturePageRatings.ValueBool(), data.FeaturePageComments.ValueBool(), data.FeaturePersonalWikis.ValueBool(), data.SecurityOpenRedirect.ValueBool(), data.SecurityIframe.ValueBool(), data.SecurityReferrerPolicy.ValueBool(), data.SecurityTrustProxy.ValueBool(), data.SecuritySRI.ValueBool(), data.SecurityHSTS.ValueBool(), int(data.SecurityHSTSDuration.ValueInt64()), data.SecurityCSP.ValueBool(), data.SecurityCSPDirectives.ValueString(), int(data.UploadMaxFileSize.ValueInt64()), int(data.UploadMaxFiles.ValueInt64()), data.UploadScanSVG.ValueBool(), data.UploadForceDownload.ValueBool())
-	if err != nil {
-		resp.Diagnostics.AddError("could not update wiki.js site config", err.Error())
-		return
-	}
-	if !wresp.Site.UpdateConfig.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("wiki.js refused site config update: %s", wresp.Site.UpdateConfig.ResponseResult.Slug), wresp.Site.UpdateConfig.ResponseResult.Message)
+	if err := responseError(err, &wresp.Site.UpdateConfig.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "wiki.js refused site config update", err)
 		return
 	}
 
@@ -460,3 +480,7 @@ func (r *siteConfigResource) Update(ctx context.Context, req resource.UpdateRequ
 func (r *siteConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	resp.Diagnostics.AddWarning("Site Config has no factory defaults", "Deleting the wikijs_site_config resource just removes the resource from the terraform state. No wiki.js config is changed.")
 }
+
+func (r *siteConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccSiteConfigResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfigResourceConfig(srv, "Handbook"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_site_config.test", "title", "Handbook"),
+					resource.TestCheckResourceAttr("wikijs_site_config.test", "robots.#", "2"),
+					resource.TestCheckResourceAttr("wikijs_site_config.test", "upload_max_files", "20"),
+					testAccCheckSiteConfig(srv, "title", "Handbook"),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_site_config.test",
+				ImportState:                          true,
+				ImportStateId:                        "site_config",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "host",
+			},
+			{
+				Config: testAccSiteConfigResourceConfig(srv, "Knowledge Base"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_site_config.test", "title", "Knowledge Base"),
+					testAccCheckSiteConfig(srv, "title", "Knowledge Base"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.SiteConfig["title"] = "Wiki.js" })
+				},
+				Config: testAccSiteConfigResourceConfig(srv, "Knowledge Base"),
+				Check:  testAccCheckSiteConfig(srv, "title", "Knowledge Base"),
+			},
+		},
+		// The site config is left alone on destroy
+		CheckDestroy: testAccCheckSiteConfig(srv, "title", "Knowledge Base"),
+	})
+}
+
+func testAccSiteConfigResourceConfig(srv *wikijstest.Server, title string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_site_config" "test" {
+  host             = "https://wiki.example.com"
+  title            = %q
+  upload_max_files = 20
+}
+`, title)
+}
+
+func testAccCheckSiteConfig(srv *wikijstest.Server, key string, expected interface{}) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var actual interface{}
+		srv.Update(func(store *wikijstest.Store) { actual = store.SiteConfig[key] })
+		if actual != expected {
+			return fmt.Errorf("expected site config %s to be %v, got %v", key, expected, actual)
+		}
+		return nil
+	}
+}
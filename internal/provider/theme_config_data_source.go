@@ -106,7 +106,7 @@ func (d *themeConfigDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	wresp, err := wikijs.GetThemeConfig(ctx, d.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get Theme Config Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Theme Config Request failed", err)
 	}
 
 	state.Theme = types.StringValue(wresp.Theming.Config.Theme)
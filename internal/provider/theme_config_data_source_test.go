@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccThemeConfigDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_theme_config" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_theme_config.test", "theme", "default"),
+					resource.TestCheckResourceAttr("data.wikijs_theme_config.test", "dark_mode", "false"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.ThemeConfig["darkMode"] = true })
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("data.wikijs_theme_config.test", "dark_mode", "true"),
+			},
+		},
+	})
+}
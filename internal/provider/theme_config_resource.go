@@ -27,6 +27,7 @@ var (
 	_ resource.Resource                   = &themeConfigResource{}
 	_ resource.ResourceWithConfigure      = &themeConfigResource{}
 	_ resource.ResourceWithValidateConfig = &themeConfigResource{}
+	_ resource.ResourceWithImportState    = &themeConfigResource{}
 )
 
 func NewThemeConfigResource() resource.Resource {
@@ -175,12 +176,8 @@ func (r *themeConfigResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	wresp, err := wikijs.SetThemeConfig(ctx, r.client.graphql, data.Theme.ValueString(), data.Iconset.ValueString(), data.DarkMode.ValueBool(), data.TocPosition.ValueString(), data.InjectCSS.ValueString(), data.InjectHead.ValueString(), data.InjectBody.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Could not update wiki.js theme config", err.Error())
-		return
-	}
-	if !wresp.Theming.SetConfig.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("wiki.js refused theme config update: %s", wresp.Theming.SetConfig.ResponseResult.Slug), wresp.Theming.SetConfig.ResponseResult.Message)
+	if err := responseError(err, &wresp.Theming.SetConfig.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "wiki.js refused theme config update", err)
 		return
 	}
 
@@ -196,7 +193,8 @@ func (r *themeConfigResource) Read(ctx context.Context, req resource.ReadRequest
 
 	wresp, err := wikijs.GetThemeConfig(ctx, r.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Could not query wiki.js graphql api", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Could not query wiki.js graphql api", err)
+		return
 	}
 	state.Theme = types.StringValue(wresp.Theming.Config.Theme)
 	state.Iconset = types.StringValue(wresp.Theming.Config.Iconset)
@@ -206,7 +204,7 @@ func (r *themeConfigResource) Read(ctx context.Context, req resource.ReadRequest
 	state.InjectHead = types.StringValue(wresp.Theming.Config.InjectHead)
 	state.InjectBody = types.StringValue(wresp.Theming.Config.InjectBody)
 
-	resp.Diagnostics.Append(req.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
 func (r *themeConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -217,12 +215,8 @@ func (r *themeConfigResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	wresp, err := wikijs.SetThemeConfig(ctx, r.client.graphql, data.Theme.ValueString(), data.Iconset.ValueString(), data.DarkMode.ValueBool(), data.TocPosition.ValueString(), data.InjectCSS.ValueString(), data.InjectHead.ValueString(), data.InjectBody.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Could not update wiki.js theme config", err.Error())
-		return
-	}
-	if !wresp.Theming.SetConfig.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("wiki.js refused theme config update: %s", wresp.Theming.SetConfig.ResponseResult.Slug), wresp.Theming.SetConfig.ResponseResult.Message)
+	if err := responseError(err, &wresp.Theming.SetConfig.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "wiki.js refused theme config update", err)
 		return
 	}
 
@@ -237,13 +231,13 @@ func (r *themeConfigResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	wresp, err := wikijs.SetThemeConfig(ctx, r.client.graphql, defaultThemeConfig.theme, defaultThemeConfig.iconset, defaultThemeConfig.darkMode, defaultThemeConfig.tocPosition, defaultThemeConfig.injectCSS, defaultThemeConfig.injectHead, defaultThemeConfig.injectBody)
-	if err != nil {
-		resp.Diagnostics.AddError("Request to reset the Theme Config to Defaults has failed", err.Error())
-		return
-	}
-	if !wresp.Theming.SetConfig.ResponseResult.Succeeded {
-		resp.Diagnostics.AddError(fmt.Sprintf("Could not reset the Theme Config to Defaults: %s", wresp.Theming.SetConfig.ResponseResult.Slug), wresp.Theming.SetConfig.ResponseResult.Message)
+	if err := responseError(err, &wresp.Theming.SetConfig.ResponseResult); err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Could not reset the Theme Config to Defaults", err)
 		return
 	}
 	//resp.Diagnostics.AddWarning("Theme Config has no factory defaults", "Deleting the wikijs_theme_config resource just removes the resource from the terraform state. No wiki.js config is changed.")
 }
+
+func (r *themeConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importSingleton(ctx, resp)
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccThemeConfigResource(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccThemeConfigResourceConfig(srv, true, "right"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_theme_config.test", "dark_mode", "true"),
+					resource.TestCheckResourceAttr("wikijs_theme_config.test", "inject_css", ""),
+					testAccCheckThemeConfig(srv, "tocPosition", "right"),
+				),
+			},
+			{
+				ResourceName:                         "wikijs_theme_config.test",
+				ImportState:                          true,
+				ImportStateId:                        "theme_config",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "theme",
+			},
+			{
+				Config: testAccThemeConfigResourceConfig(srv, false, "off"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("wikijs_theme_config.test", "dark_mode", "false"),
+					testAccCheckThemeConfig(srv, "tocPosition", "off"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) { store.ThemeConfig["tocPosition"] = "left" })
+				},
+				Config: testAccThemeConfigResourceConfig(srv, false, "off"),
+				Check:  testAccCheckThemeConfig(srv, "tocPosition", "off"),
+			},
+		},
+		// The defaults are restored on destroy
+		CheckDestroy: testAccCheckThemeConfig(srv, "tocPosition", "left"),
+	})
+}
+
+func testAccThemeConfigResourceConfig(srv *wikijstest.Server, darkMode bool, tocPosition string) string {
+	return testAccProviderConfig(srv) + fmt.Sprintf(`
+resource "wikijs_theme_config" "test" {
+  theme        = "default"
+  iconset      = "mdi"
+  dark_mode    = %t
+  toc_position = %q
+}
+`, darkMode, tocPosition)
+}
+
+func testAccCheckThemeConfig(srv *wikijstest.Server, key string, expected interface{}) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var actual interface{}
+		srv.Update(func(store *wikijstest.Store) { actual = store.ThemeConfig[key] })
+		if actual != expected {
+			return fmt.Errorf("expected theme config %s to be %v, got %v", key, expected, actual)
+		}
+		return nil
+	}
+}
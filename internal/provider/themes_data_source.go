@@ -90,7 +90,7 @@ func (d *themesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 	wresp, err := wikijs.GetThemes(ctx, d.client.graphql)
 	if err != nil {
-		resp.Diagnostics.AddError("Get Themes Request failed", err.Error())
+		addErrorDiagnostic(&resp.Diagnostics, "Get Themes Request failed", err)
 	}
 
 	// Clear state.Themes to reassign values
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccThemesDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccProviderConfig(srv) + `
+data "wikijs_themes" "test" {
+  themes = []
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_themes.test", "themes.#", "1"),
+					resource.TestCheckResourceAttr("data.wikijs_themes.test", "themes.0.key", "default"),
+				),
+			},
+			{
+				PreConfig: func() {
+					srv.Update(func(store *wikijstest.Store) {
+						store.Themes = append(store.Themes, &wikijstest.Theme{Key: "custom", Title: "Custom", Author: "ACME"})
+					})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_themes.test", "themes.#", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_themes.test", "themes.1.author", "ACME"),
+				),
+			},
+		},
+	})
+}
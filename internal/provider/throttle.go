@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// throttleTransport limits how many requests are sent to wiki.js at the same
+// time and how many are started per second, so Terraform's parallelism does
+// not overload small instances. A request holds its slot until its response
+// body is closed. The wait and duration of every request are logged with
+// running totals per GraphQL operation.
+type throttleTransport struct {
+	base http.RoundTripper
+	// slots has one element per request in flight, nil without a limit
+	slots chan struct{}
+	// interval is the minimum time between two requests, zero without a limit
+	interval time.Duration
+
+	mu      sync.Mutex
+	next    time.Time
+	metrics map[string]*operationMetrics
+}
+
+// operationMetrics are the running totals of one GraphQL operation.
+type operationMetrics struct {
+	requests int64
+	wait     time.Duration
+	duration time.Duration
+}
+
+// newThrottleTransport returns a transport sending at most maxConcurrent
+// requests at once and perSecond requests per second. Zero disables a limit.
+func newThrottleTransport(base http.RoundTripper, maxConcurrent int64, perSecond float64) *throttleTransport {
+	t := &throttleTransport{
+		base:    base,
+		metrics: map[string]*operationMetrics{},
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+
+	return t
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if delay := t.reserve(); delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			t.release()
+			return nil, ctx.Err()
+		}
+	}
+
+	wait := time.Since(start)
+	sent := time.Now()
+	done := func() {
+		t.release()
+		t.record(req, wait, time.Since(sent))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		done()
+		return nil, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: done}
+
+	return resp, nil
+}
+
+// reserve books the next free start time and returns how long to wait for it.
+func (t *throttleTransport) reserve() time.Duration {
+	if t.interval == 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	delay := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+
+	return delay
+}
+
+func (t *throttleTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// record adds a finished request to the totals of its operation and logs them.
+func (t *throttleTransport) record(req *http.Request, wait time.Duration, duration time.Duration) {
+	operation := operationName(req)
+
+	t.mu.Lock()
+	m, ok := t.metrics[operation]
+	if !ok {
+		m = &operationMetrics{}
+		t.metrics[operation] = m
+	}
+	m.requests++
+	m.wait += wait
+	m.duration += duration
+	fields := map[string]interface{}{
+		"graphql_operation":       operation,
+		"wait_ms":                 wait.Milliseconds(),
+		"duration_ms":             duration.Milliseconds(),
+		"operation_requests":      m.requests,
+		"operation_wait_ms":       m.wait.Milliseconds(),
+		"operation_duration_ms":   m.duration.Milliseconds(),
+		"concurrent_requests":     len(t.slots),
+		"max_concurrent_requests": cap(t.slots),
+	}
+	t.mu.Unlock()
+
+	tflog.Debug(req.Context(), "wiki.js request metrics", fields)
+}
+
+// operationName returns the GraphQL operation of the request, or the method
+// and path of other requests like the health check.
+func operationName(req *http.Request) string {
+	if body, ok := requestBody(req); ok {
+		var op struct {
+			OperationName string `json:"operationName"`
+		}
+		if err := json.Unmarshal(body, &op); err == nil && op.OperationName != "" {
+			return op.OperationName
+		}
+	}
+
+	return req.Method + " " + req.URL.Path
+}
+
+// releaseBody gives back the slot of a request once its body was consumed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+)
+
+// concurrencyServer answers GraphQL requests after a delay and reports the
+// highest number of requests it handled at the same time.
+func concurrencyServer(t *testing.T, delay time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var current, highest atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			h := highest.Load()
+			if n <= h || highest.CompareAndSwap(h, n) {
+				break
+			}
+		}
+
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"authentication":{"apiState":true}}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &highest
+}
+
+func testThrottleClient(url string, maxConcurrent int64, perSecond float64) graphql.Client {
+	return graphql.NewClient(url, &http.Client{
+		Transport: newThrottleTransport(http.DefaultTransport, maxConcurrent, perSecond),
+	})
+}
+
+func TestThrottleTransportLimitsConcurrency(t *testing.T) {
+	srv, highest := concurrencyServer(t, 20*time.Millisecond)
+	client := testThrottleClient(srv.URL, 2, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := wikijs.GetApiState(context.Background(), client); err != nil {
+				t.Errorf("expected query to succeed, got: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := highest.Load(); got != 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestThrottleTransportLimitsRate(t *testing.T) {
+	srv, _ := concurrencyServer(t, 0)
+	client := testThrottleClient(srv.URL, 0, 50)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := wikijs.GetApiState(context.Background(), client); err != nil {
+			t.Fatalf("expected query to succeed, got: %s", err)
+		}
+	}
+
+	// The first request is sent immediately, the others 20ms apart
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected 5 requests to take at least 80ms, took %s", elapsed)
+	}
+}
+
+func TestThrottleTransportHonoursCancellation(t *testing.T) {
+	srv, _ := concurrencyServer(t, 0)
+	client := testThrottleClient(srv.URL, 0, 0.1)
+
+	if _, err := wikijs.GetApiState(context.Background(), client); err != nil {
+		t.Fatalf("expected first query to succeed, got: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := wikijs.GetApiState(ctx, client); err == nil {
+		t.Fatal("expected throttled query to fail when its context is done")
+	}
+}
+
+func TestThrottleTransportExcludesWaitFromTimeout(t *testing.T) {
+	srv, highest := concurrencyServer(t, 300*time.Millisecond)
+
+	// The transports as the provider stacks them
+	throttle := newThrottleTransport(&timeoutTransport{base: http.DefaultTransport, timeout: 500 * time.Millisecond}, 1, 0)
+	client := graphql.NewClient(srv.URL, &http.Client{
+		Transport: &retryTransport{
+			base:       throttle,
+			maxRetries: 3,
+			minBackoff: time.Millisecond,
+			maxBackoff: 10 * time.Millisecond,
+		},
+	})
+
+	// Requests queued behind others wait longer than the timeout, only the
+	// time wiki.js takes to answer counts
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := wikijs.DeletePage(context.Background(), client, 1); err != nil {
+				t.Errorf("expected mutation to succeed, got: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := highest.Load(); got != 1 {
+		t.Errorf("expected at most 1 concurrent request, got %d", got)
+	}
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	srv, _ := concurrencyServer(t, 200*time.Millisecond)
+	client := graphql.NewClient(srv.URL, &http.Client{
+		Transport: &timeoutTransport{base: http.DefaultTransport, timeout: 50 * time.Millisecond},
+	})
+
+	if _, err := wikijs.GetApiState(context.Background(), client); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected slow request to time out, got: %v", err)
+	}
+}
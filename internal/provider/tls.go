@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// transportOptions configures the connection to wiki.js.
+type transportOptions struct {
+	caCertPEM          string
+	caCertFile         string
+	clientCert         string
+	clientKey          string
+	insecureSkipVerify bool
+	proxyUrl           string
+	headers            map[string]string
+}
+
+// newBaseTransport builds the HTTP transport every request to wiki.js goes
+// through, including logins.
+func newBaseTransport(opts transportOptions) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.insecureSkipVerify,
+	}
+
+	if opts.caCertPEM != "" || opts.caCertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if opts.caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(opts.caCertPEM)) {
+			return nil, errors.New("ca_cert_pem does not contain a PEM encoded certificate")
+		}
+
+		if opts.caCertFile != "" {
+			pem, err := os.ReadFile(opts.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read ca_cert_file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca_cert_file %s does not contain a PEM encoded certificate", opts.caCertFile)
+			}
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if opts.clientCert != "" || opts.clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(opts.clientCert), []byte(opts.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.proxyUrl != "" {
+		proxy, err := url.Parse(opts.proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if len(opts.headers) > 0 {
+		return &headerTransport{base: transport, headers: opts.headers}, nil
+	}
+
+	return transport, nil
+}
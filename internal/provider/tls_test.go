@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCert returns a self-signed certificate for usage and its key as PEM.
+func testCert(t *testing.T, usage x509.ExtKeyUsage) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-wikijs test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+// serverCertPEM returns the certificate of a TLS test server as PEM.
+func serverCertPEM(srv *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+}
+
+func testGet(t *testing.T, opts transportOptions, url string) error {
+	t.Helper()
+
+	transport, err := newBaseTransport(opts)
+	if err != nil {
+		t.Fatalf("expected transport, got: %s", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func TestBaseTransportServerCert(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCertPEM(srv)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	untrusted, _ := testCert(t, x509.ExtKeyUsageServerAuth)
+
+	tests := map[string]struct {
+		opts transportOptions
+		ok   bool
+	}{
+		"system pool":          {transportOptions{}, false},
+		"ca_cert_pem":          {transportOptions{caCertPEM: serverCertPEM(srv)}, true},
+		"ca_cert_file":         {transportOptions{caCertFile: caFile}, true},
+		"untrusted ca":         {transportOptions{caCertPEM: untrusted}, false},
+		"insecure_skip_verify": {transportOptions{insecureSkipVerify: true}, true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := testGet(t, test.opts, srv.URL)
+			if test.ok && err != nil {
+				t.Errorf("expected server certificate to be trusted, got: %s", err)
+			}
+			if !test.ok && err == nil {
+				t.Error("expected server certificate to be rejected")
+			}
+		})
+	}
+}
+
+func TestBaseTransportClientCert(t *testing.T) {
+	clientCert, clientKey := testCert(t, x509.ExtKeyUsageClientAuth)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientCert))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	if err := testGet(t, transportOptions{caCertPEM: serverCertPEM(srv)}, srv.URL); err == nil {
+		t.Error("expected request without client certificate to be rejected")
+	}
+
+	opts := transportOptions{caCertPEM: serverCertPEM(srv), clientCert: clientCert, clientKey: clientKey}
+	if err := testGet(t, opts, srv.URL); err != nil {
+		t.Errorf("expected request with client certificate to succeed, got: %s", err)
+	}
+}
+
+func TestBaseTransportProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.Host
+	}))
+	t.Cleanup(proxy.Close)
+
+	if err := testGet(t, transportOptions{proxyUrl: proxy.URL}, "http://wiki.example.com/graphql"); err != nil {
+		t.Fatalf("expected request through proxy, got: %s", err)
+	}
+	if proxied != "wiki.example.com" {
+		t.Errorf("expected proxy to receive request for wiki.example.com, got %q", proxied)
+	}
+}
+
+func TestBaseTransportHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	t.Cleanup(srv.Close)
+
+	transport, err := newBaseTransport(transportOptions{headers: map[string]string{"X-Auth-Request": "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got.Get("X-Auth-Request") != "secret" {
+		t.Errorf("expected header to arrive at the server, got %v", got)
+	}
+	if req.Header.Get("X-Auth-Request") != "" {
+		t.Error("expected the original request to be left unchanged")
+	}
+}
+
+func TestBaseTransportErrors(t *testing.T) {
+	cert, _ := testCert(t, x509.ExtKeyUsageClientAuth)
+	_, otherKey := testCert(t, x509.ExtKeyUsageClientAuth)
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("no certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		opts transportOptions
+		err  string
+	}{
+		"invalid ca_cert_pem":   {transportOptions{caCertPEM: "no certificate"}, "ca_cert_pem"},
+		"missing ca_cert_file":  {transportOptions{caCertFile: filepath.Join(t.TempDir(), "missing.pem")}, "could not read ca_cert_file"},
+		"invalid ca_cert_file":  {transportOptions{caCertFile: invalid}, "does not contain a PEM encoded certificate"},
+		"mismatched client key": {transportOptions{clientCert: cert, clientKey: otherKey}, "client certificate"},
+		"invalid proxy_url":     {transportOptions{proxyUrl: "://proxy"}, "proxy_url"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newBaseTransport(test.opts)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error containing %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
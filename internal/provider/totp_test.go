@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTotpCode(t *testing.T) {
+	// Test vectors of RFC 6238 appendix B for SHA-1, truncated to 6 digits.
+	// The secret is the ASCII string "12345678901234567890".
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	for unix, expected := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := totpCode(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != expected {
+			t.Errorf("expected code %s at %d, got %s", expected, unix, code)
+		}
+	}
+}
+
+func TestTotpCodeSecretFormat(t *testing.T) {
+	// wiki.js shows the secret in groups, users may copy it in lower case
+	code, err := totpCode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "287082" {
+		t.Errorf("expected code 287082, got %s", code)
+	}
+
+	if _, err := totpCode("not base32!", time.Now()); err == nil {
+		t.Errorf("expected invalid secret to fail")
+	}
+}
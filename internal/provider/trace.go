@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces secrets in the log output.
+const redacted = "***"
+
+// secretVariables are GraphQL variables and JSON fields that never show up in logs.
+var secretVariables = map[string]bool{
+	"password":             true,
+	"newPassword":          true,
+	"adminPassword":        true,
+	"adminPasswordConfirm": true,
+	"jwt":                  true,
+	"token":                true,
+	"apiKey":               true,
+	"dkimPrivateKey":       true,
+	"continuationToken":    true,
+	"securityCode":         true,
+}
+
+// secretConfigs are input lists whose config may hold credentials of third
+// party services, e.g. OAuth client secrets or search engine API keys.
+var secretConfigs = map[string]bool{
+	"strategies": true,
+	"engines":    true,
+}
+
+// secretHeaders are HTTP headers carrying credentials.
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "New-Jwt"}
+
+// traceTransport logs every request to wiki.js through tflog, so failed
+// applies can be debugged with TF_LOG_PROVIDER=DEBUG. Secrets in GraphQL
+// variables and headers are redacted.
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"http_method":          req.Method,
+		"http_url":             req.URL.String(),
+		"http_request_headers": redactHeaders(req.Header),
+	}
+	if body, ok := requestBody(req); ok {
+		var op struct {
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+		}
+		if err := json.Unmarshal(body, &op); err == nil && op.OperationName != "" {
+			fields["graphql_operation"] = op.OperationName
+			fields["graphql_variables"] = redactValue("", op.Variables)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "wiki.js request failed", fields)
+		return nil, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	if errs := responseErrors(resp); len(errs) > 0 {
+		fields["graphql_errors"] = errs
+	}
+	tflog.Debug(ctx, "wiki.js request", fields)
+
+	return resp, nil
+}
+
+// requestBody returns a copy of the request body without consuming it.
+func requestBody(req *http.Request) ([]byte, bool) {
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	return b, err == nil
+}
+
+// responseErrors returns the messages of GraphQL errors in the response. The
+// response body is restored for the caller.
+func responseErrors(resp *http.Response) []string {
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	var result struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil
+	}
+
+	var messages []string
+	for _, e := range result.Errors {
+		messages = append(messages, e.Message)
+	}
+
+	return messages
+}
+
+// redactValue returns a copy of a decoded JSON value with all secrets
+// replaced. key is the name of the field holding the value.
+func redactValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			switch {
+			case secretVariables[k]:
+				out[k] = redacted
+			case k == "config" && secretConfigs[key]:
+				out[k] = redacted
+			default:
+				out[k] = redactValue(k, e)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			// List items belong to the field holding the list
+			out[i] = redactValue(key, e)
+		}
+		return out
+	}
+
+	return value
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for k := range header {
+		out[k] = header.Get(k)
+	}
+	for _, k := range secretHeaders {
+		if _, ok := out[k]; ok {
+			out[k] = redacted
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRedactValue(t *testing.T) {
+	variables := map[string]interface{}{
+		"username": "admin@example.com",
+		"password": "secret",
+		"strategies": []interface{}{
+			map[string]interface{}{
+				"key":    "github",
+				"config": []interface{}{map[string]interface{}{"key": "clientSecret", "value": "secret"}},
+			},
+		},
+		"renderers": []interface{}{
+			map[string]interface{}{
+				"key":    "htmlCore",
+				"config": []interface{}{map[string]interface{}{"key": "linkify", "value": "true"}},
+			},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"username": "admin@example.com",
+		"password": redacted,
+		"strategies": []interface{}{
+			map[string]interface{}{
+				"key":    "github",
+				"config": redacted,
+			},
+		},
+		"renderers": []interface{}{
+			map[string]interface{}{
+				"key":    "htmlCore",
+				"config": []interface{}{map[string]interface{}{"key": "linkify", "value": "true"}},
+			},
+		},
+	}
+
+	if got := redactValue("", variables); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if variables["password"] != "secret" {
+		t.Errorf("redactValue modified its input")
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Authorization", "Bearer secret")
+	header.Set("Cookie", "jwt=secret")
+
+	expected := map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": redacted,
+		"Cookie":        redacted,
+	}
+	if got := redactHeaders(header); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bearerTransport adds a static API key as bearer token to every request.
+type bearerTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	return t.base.RoundTrip(req)
+}
+
+// errorTransport fails every request, it is used when the provider could not
+// be configured yet.
+type errorTransport struct {
+	err error
+}
+
+func (t *errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// headerTransport adds static headers to every request, e.g. for an identity
+// aware proxy in front of wiki.js.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// retryableMutations lists mutations which leave wiki.js in the same state no
+// matter how often they are sent, so they can be retried like queries.
+var retryableMutations = map[string]bool{
+	"Login":             true,
+	"UpdateSiteConfig":  true,
+	"UpdateGroup":       true,
+	"SetLocalization":   true,
+	"SetThemeConfig":    true,
+	"SetApiState":       true,
+	"SetAuthStrategies": true,
+	"SetRenderers":      true,
+	"SetSearchEngines":  true,
+}
+
+// retryTransport retries failed GraphQL requests with exponential backoff.
+// Only queries and mutations listed in retryableMutations are retried, any
+// other request is sent exactly once.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if retryableRequest(req) {
+		retries = t.maxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.attempt(req)
+		if attempt >= retries || !retryableResponse(resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			// Retry-After is capped so a misbehaving proxy can not stall the apply
+			if after, ok := retryAfter(resp); ok {
+				delay = after
+				if delay > t.maxBackoff {
+					delay = t.maxBackoff
+				}
+			}
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attempt sends the request once with a fresh copy of its body.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the original request
+	out := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		out.Body = body
+	}
+
+	return t.base.RoundTrip(out)
+}
+
+// backoff returns the exponential delay before the next attempt with half of
+// it randomized to spread concurrent retries.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.maxBackoff
+	if attempt < 32 && t.minBackoff<<attempt < t.maxBackoff {
+		delay = t.minBackoff << attempt
+	}
+	if delay <= 1 {
+		return delay
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
+
+// retryableRequest reports whether the request is a GraphQL query or a
+// mutation that is safe to send again.
+func retryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if req.GetBody == nil {
+		return req.Method == http.MethodGet || req.Method == http.MethodHead
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+
+	var op struct {
+		OperationName string `json:"operationName"`
+		Query         string `json:"query"`
+	}
+	if err := json.NewDecoder(body).Decode(&op); err != nil {
+		return false
+	}
+
+	query := strings.TrimSpace(op.Query)
+	if strings.HasPrefix(query, "query") || strings.HasPrefix(query, "{") {
+		return true
+	}
+
+	return strings.HasPrefix(query, "mutation") && retryableMutations[op.OperationName]
+}
+
+// retryableResponse classifies transport errors and HTTP status codes that
+// are worth another attempt.
+func retryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter parses the Retry-After header given as seconds or HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// timeoutTransport bounds every request sent to wiki.js, from sending it until
+// its response body is closed. It sits below the throttle, so the time a
+// request waits for its turn does not count.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelBody releases the request context once the body was consumed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
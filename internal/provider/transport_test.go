@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+)
+
+// faultServer answers GraphQL requests after failing the first n of them
+// with the given status code.
+func faultServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"authentication":{"apiState":true,"setApiState":{"responseResult":{"succeeded":true}}},"pages":{"delete":{"responseResult":{"succeeded":true}}},"system":{"info":{"currentVersion":"2.5.300"}}}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func testRetryClient(url string, maxRetries int) graphql.Client {
+	return graphql.NewClient(url, &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: maxRetries,
+			minBackoff: time.Millisecond,
+			maxBackoff: 10 * time.Millisecond,
+		},
+	})
+}
+
+func TestRetryTransportRetriesQueries(t *testing.T) {
+	srv, requests := faultServer(t, 2, http.StatusBadGateway, nil)
+
+	wresp, err := wikijs.GetApiState(context.Background(), testRetryClient(srv.URL, 3))
+	if err != nil {
+		t.Fatalf("expected query to succeed after retries, got: %s", err)
+	}
+	if !wresp.Authentication.ApiState {
+		t.Errorf("expected api state from final response")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	srv, requests := faultServer(t, 10, http.StatusServiceUnavailable, nil)
+
+	if _, err := wikijs.GetApiState(context.Background(), testRetryClient(srv.URL, 2)); err == nil {
+		t.Fatal("expected query to fail after exhausting retries")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetryTransportRetriesIdempotentMutations(t *testing.T) {
+	srv, requests := faultServer(t, 1, http.StatusGatewayTimeout, nil)
+
+	if _, err := wikijs.SetApiState(context.Background(), testRetryClient(srv.URL, 3), true); err != nil {
+		t.Fatalf("expected mutation to succeed after retry, got: %s", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestRetryTransportDoesNotRetryOtherMutations(t *testing.T) {
+	srv, requests := faultServer(t, 1, http.StatusBadGateway, nil)
+
+	if _, err := wikijs.DeletePage(context.Background(), testRetryClient(srv.URL, 3), 1); err == nil {
+		t.Fatal("expected mutation to fail without retry")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryTransportDoesNotRetryClientErrors(t *testing.T) {
+	srv, requests := faultServer(t, 1, http.StatusBadRequest, nil)
+
+	if _, err := wikijs.GetApiState(context.Background(), testRetryClient(srv.URL, 3)); err == nil {
+		t.Fatal("expected query to fail without retry")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	srv, requests := faultServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	client := graphql.NewClient(srv.URL, &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: 1,
+			minBackoff: time.Millisecond,
+			maxBackoff: 10 * time.Second,
+		},
+	})
+
+	start := time.Now()
+	if _, err := wikijs.GetApiState(context.Background(), client); err != nil {
+		t.Fatalf("expected query to succeed after retry, got: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After, retried after %s", elapsed)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestRetryTransportCapsRetryAfter(t *testing.T) {
+	srv, requests := faultServer(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"3600"}})
+
+	start := time.Now()
+	if _, err := wikijs.GetApiState(context.Background(), testRetryClient(srv.URL, 1)); err != nil {
+		t.Fatalf("expected query to succeed after retry, got: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Retry-After to be capped at the maximum backoff, retried after %s", elapsed)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestRetryTransportRetriesConnectionErrors(t *testing.T) {
+	srv, requests := faultServer(t, 0, http.StatusOK, nil)
+
+	// Drop the connection of the first request without answering
+	var dropped atomic.Bool
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if dropped.CompareAndSwap(false, true) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	if _, err := wikijs.GetApiState(context.Background(), testRetryClient(srv.URL, 3)); err != nil {
+		t.Fatalf("expected query to succeed after retry, got: %s", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 answered request, got %d", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		header string
+		delay  time.Duration
+		ok     bool
+	}{
+		"missing": {"", 0, false},
+		"seconds": {"3", 3 * time.Second, true},
+		"past":    {"Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+		"invalid": {"soon", 0, false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if test.header != "" {
+				resp.Header.Set("Retry-After", test.header)
+			}
+
+			delay, ok := retryAfter(resp)
+			if delay != test.delay || ok != test.ok {
+				t.Errorf("expected (%s, %t), got (%s, %t)", test.delay, test.ok, delay, ok)
+			}
+		})
+	}
+}
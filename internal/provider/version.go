@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+)
+
+// capability is a part of the wiki.js API that is not available in every
+// version supported by the provider.
+type capability struct {
+	description string
+	minVersion  *version.Version
+	// attributes are the resource and data source attributes that need it
+	attributes []path.Path
+}
+
+var (
+	// capabilityEditShortcuts covers the edit_* settings of the site config.
+	capabilityEditShortcuts = capability{
+		description: "the edit shortcut settings of the site config",
+		minVersion:  version.Must(version.NewVersion("2.5.294")),
+		attributes: []path.Path{
+			path.Root("edit_fab"),
+			path.Root("edit_menu_bar"),
+			path.Root("edit_menu_btn"),
+			path.Root("edit_menu_external_btn"),
+			path.Root("edit_menu_external_name"),
+			path.Root("edit_menu_external_icon"),
+			path.Root("edit_menu_external_url"),
+		},
+	}
+)
+
+// serverVersion returns the version of wiki.js as reported by system.info.
+// Once a query succeeded its result is kept, failed queries are tried again
+// on the next call. nil is returned if the account may not read the version.
+func (c *WikiJSClient) serverVersion(ctx context.Context) (*version.Version, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.versionKnown {
+		return c.version, nil
+	}
+
+	wresp, err := wikijs.GetSystemInfo(ctx, c.graphql)
+	if isForbidden(err) {
+		// Asking again does not give the account the permission
+		c.versionKnown = true
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if wresp.System.Info.CurrentVersion != "" {
+		v, err := version.NewVersion(wresp.System.Info.CurrentVersion)
+		if err != nil {
+			return nil, err
+		}
+		c.version = v
+	}
+	c.versionKnown = true
+
+	return c.version, nil
+}
+
+// checkCapability reports an error if the connected wiki.js is too old for
+// the capability. Unknown versions are assumed to support everything.
+func (c *WikiJSClient) checkCapability(ctx context.Context, cap capability) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	v, err := c.serverVersion(ctx)
+	if err != nil || v == nil {
+		return diags
+	}
+
+	if v.LessThan(cap.minVersion) {
+		for _, attribute := range cap.attributes {
+			diags.AddAttributeError(
+				attribute,
+				"Unsupported wiki.js version",
+				fmt.Sprintf("The connected wiki.js %s does not support %s, which requires wiki.js %s or later.", v, cap.description, cap.minVersion),
+			)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &versionDataSource{}
+	_ datasource.DataSourceWithConfigure = &versionDataSource{}
+)
+
+// NewVersionDataSource is a helper function to simplify the provider implementation.
+func NewVersionDataSource() datasource.DataSource {
+	return &versionDataSource{}
+}
+
+// versionDataSource is the data source implementation.
+type versionDataSource struct {
+	client *WikiJSClient
+}
+
+type versionDataSourceModel struct {
+	CurrentVersion types.String `tfsdk:"current_version"`
+	Major          types.Int64  `tfsdk:"major"`
+	Minor          types.Int64  `tfsdk:"minor"`
+	Patch          types.Int64  `tfsdk:"patch"`
+}
+
+// Metadata returns the data source type name.
+func (d *versionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_version"
+}
+
+// Schema defines the schema for the data source.
+func (d *versionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"current_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version of the connected Wiki.JS as reported by system.info",
+			},
+			"major": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Major version",
+			},
+			"minor": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Minor version",
+			},
+			"patch": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Patch version",
+			},
+		},
+		MarkdownDescription: "The `wikijs_version` data source returns the version of the connected wiki.js.\n" +
+			"The provider uses the same version to check whether the server supports the configured features.\n" +
+			"Reading the version requires an account with the `manage:system` permission.",
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *versionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*WikiJSClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *WikiJSClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *versionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data versionDataSourceModel
+
+	v, err := d.client.serverVersion(ctx)
+	if err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Get System Info Request failed", err)
+		return
+	}
+	if v == nil {
+		resp.Diagnostics.AddError("Wiki.js version unknown", "Wiki.js did not report its version")
+		return
+	}
+
+	segments := v.Segments64()
+	data.CurrentVersion = types.StringValue(v.Original())
+	data.Major = types.Int64Value(segments[0])
+	data.Minor = types.Int64Value(segments[1])
+	data.Patch = types.Int64Value(segments[2])
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs/wikijstest"
+)
+
+func TestAccVersionDataSource(t *testing.T) {
+	srv := testAccServer(t)
+	srv.Update(func(store *wikijstest.Store) { store.Version = "2.5.301" })
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(srv) + `
+data "wikijs_version" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wikijs_version.test", "current_version", "2.5.301"),
+					resource.TestCheckResourceAttr("data.wikijs_version.test", "major", "2"),
+					resource.TestCheckResourceAttr("data.wikijs_version.test", "minor", "5"),
+					resource.TestCheckResourceAttr("data.wikijs_version.test", "patch", "301"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestServerVersionOnlyKeepsSuccess(t *testing.T) {
+	srv, requests := faultServer(t, 1, http.StatusServiceUnavailable, nil)
+	client := &WikiJSClient{graphql: graphql.NewClient(srv.URL, http.DefaultClient)}
+
+	if _, err := client.serverVersion(context.Background()); err == nil {
+		t.Fatal("expected first lookup to fail")
+	}
+
+	for i := 0; i < 2; i++ {
+		v, err := client.serverVersion(context.Background())
+		if err != nil {
+			t.Fatalf("expected lookup to be retried, got: %s", err)
+		}
+		if v.String() != "2.5.300" {
+			t.Errorf("expected version 2.5.300, got %s", v)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected the version to be queried twice, got %d requests", got)
+	}
+}
+
+func TestServerVersionForbidden(t *testing.T) {
+	srv := testAccServer(t)
+	client := &WikiJSClient{graphql: graphql.NewClient(srv.URL+"/graphql", http.DefaultClient)}
+
+	v, err := client.serverVersion(context.Background())
+	if err != nil || v != nil {
+		t.Fatalf("expected unknown version for a guest, got %v, %v", v, err)
+	}
+
+	// The unknown version is kept instead of asking again
+	srv.Close()
+	if v, err := client.serverVersion(context.Background()); err != nil || v != nil {
+		t.Errorf("expected the unknown version to be kept, got %v, %v", v, err)
+	}
+}
+
+func TestCheckCapabilityAttributes(t *testing.T) {
+	client := &WikiJSClient{version: version.Must(version.NewVersion("2.5.200")), versionKnown: true}
+
+	diags := client.checkCapability(context.Background(), capabilityEditShortcuts)
+	if len(diags) != len(capabilityEditShortcuts.attributes) {
+		t.Fatalf("expected one error per edit attribute, got %v", diags)
+	}
+	for _, d := range diags {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok || !strings.HasPrefix(withPath.Path().String(), "edit_") {
+			t.Errorf("expected error on an edit attribute, got %v", d)
+		}
+	}
+
+	client.version = version.Must(version.NewVersion("2.5.300"))
+	if diags := client.checkCapability(context.Background(), capabilityEditShortcuts); diags.HasError() {
+		t.Errorf("expected capability on wiki.js 2.5.300, got %v", diags)
+	}
+}
@@ -47,6 +47,78 @@ func (v *AuthenticationStrategyInput) GetDomainWhitelist() []string { return v.D
 // GetAutoEnrollGroups returns AuthenticationStrategyInput.AutoEnrollGroups, and is useful for accessing the field via an interface.
 func (v *AuthenticationStrategyInput) GetAutoEnrollGroups() []int { return v.AutoEnrollGroups }
 
+// CheckPageConflictsPagesPageQuery includes the requested fields of the GraphQL type PageQuery.
+type CheckPageConflictsPagesPageQuery struct {
+	CheckConflicts bool `json:"checkConflicts"`
+}
+
+// GetCheckConflicts returns CheckPageConflictsPagesPageQuery.CheckConflicts, and is useful for accessing the field via an interface.
+func (v *CheckPageConflictsPagesPageQuery) GetCheckConflicts() bool { return v.CheckConflicts }
+
+// CheckPageConflictsResponse is returned by CheckPageConflicts on success.
+type CheckPageConflictsResponse struct {
+	Pages CheckPageConflictsPagesPageQuery `json:"pages"`
+}
+
+// GetPages returns CheckPageConflictsResponse.Pages, and is useful for accessing the field via an interface.
+func (v *CheckPageConflictsResponse) GetPages() CheckPageConflictsPagesPageQuery { return v.Pages }
+
+// ConvertPagePagesPageMutation includes the requested fields of the GraphQL type PageMutation.
+type ConvertPagePagesPageMutation struct {
+	Convert ConvertPagePagesPageMutationConvertDefaultResponse `json:"convert"`
+}
+
+// GetConvert returns ConvertPagePagesPageMutation.Convert, and is useful for accessing the field via an interface.
+func (v *ConvertPagePagesPageMutation) GetConvert() ConvertPagePagesPageMutationConvertDefaultResponse {
+	return v.Convert
+}
+
+// ConvertPagePagesPageMutationConvertDefaultResponse includes the requested fields of the GraphQL type DefaultResponse.
+type ConvertPagePagesPageMutationConvertDefaultResponse struct {
+	ResponseResult ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus `json:"responseResult"`
+}
+
+// GetResponseResult returns ConvertPagePagesPageMutationConvertDefaultResponse.ResponseResult, and is useful for accessing the field via an interface.
+func (v *ConvertPagePagesPageMutationConvertDefaultResponse) GetResponseResult() ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus {
+	return v.ResponseResult
+}
+
+// ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus includes the requested fields of the GraphQL type ResponseStatus.
+type ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus struct {
+	Succeeded bool   `json:"succeeded"`
+	ErrorCode int    `json:"errorCode"`
+	Slug      string `json:"slug"`
+	Message   string `json:"message"`
+}
+
+// GetSucceeded returns ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus.Succeeded, and is useful for accessing the field via an interface.
+func (v *ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus) GetSucceeded() bool {
+	return v.Succeeded
+}
+
+// GetErrorCode returns ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus.ErrorCode, and is useful for accessing the field via an interface.
+func (v *ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus) GetErrorCode() int {
+	return v.ErrorCode
+}
+
+// GetSlug returns ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus.Slug, and is useful for accessing the field via an interface.
+func (v *ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus) GetSlug() string {
+	return v.Slug
+}
+
+// GetMessage returns ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus.Message, and is useful for accessing the field via an interface.
+func (v *ConvertPagePagesPageMutationConvertDefaultResponseResponseResultResponseStatus) GetMessage() string {
+	return v.Message
+}
+
+// ConvertPageResponse is returned by ConvertPage on success.
+type ConvertPageResponse struct {
+	Pages ConvertPagePagesPageMutation `json:"pages"`
+}
+
+// GetPages returns ConvertPageResponse.Pages, and is useful for accessing the field via an interface.
+func (v *ConvertPageResponse) GetPages() ConvertPagePagesPageMutation { return v.Pages }
+
 // CreateApiKeyAuthenticationAuthenticationMutation includes the requested fields of the GraphQL type AuthenticationMutation.
 type CreateApiKeyAuthenticationAuthenticationMutation struct {
 	CreateApiKey CreateApiKeyAuthenticationAuthenticationMutationCreateApiKeyAuthenticationCreateApiKeyResponse `json:"createApiKey"`
@@ -1465,6 +1537,60 @@ type GetPageByPathResponse struct {
 // GetPages returns GetPageByPathResponse.Pages, and is useful for accessing the field via an interface.
 func (v *GetPageByPathResponse) GetPages() GetPageByPathPagesPageQuery { return v.Pages }
 
+// GetPageConflictLatestPagesPageQuery includes the requested fields of the GraphQL type PageQuery.
+type GetPageConflictLatestPagesPageQuery struct {
+	ConflictLatest GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest `json:"conflictLatest"`
+}
+
+// GetConflictLatest returns GetPageConflictLatestPagesPageQuery.ConflictLatest, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestPagesPageQuery) GetConflictLatest() GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest {
+	return v.ConflictLatest
+}
+
+// GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest includes the requested fields of the GraphQL type PageConflictLatest.
+type GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest struct {
+	Id         int    `json:"id"`
+	AuthorId   string `json:"authorId"`
+	AuthorName string `json:"authorName"`
+	Content    string `json:"content"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+// GetId returns GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest.Id, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest) GetId() int {
+	return v.Id
+}
+
+// GetAuthorId returns GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest.AuthorId, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest) GetAuthorId() string {
+	return v.AuthorId
+}
+
+// GetAuthorName returns GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest.AuthorName, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest) GetAuthorName() string {
+	return v.AuthorName
+}
+
+// GetContent returns GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest.Content, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest) GetContent() string {
+	return v.Content
+}
+
+// GetUpdatedAt returns GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestPagesPageQueryConflictLatestPageConflictLatest) GetUpdatedAt() string {
+	return v.UpdatedAt
+}
+
+// GetPageConflictLatestResponse is returned by GetPageConflictLatest on success.
+type GetPageConflictLatestResponse struct {
+	Pages GetPageConflictLatestPagesPageQuery `json:"pages"`
+}
+
+// GetPages returns GetPageConflictLatestResponse.Pages, and is useful for accessing the field via an interface.
+func (v *GetPageConflictLatestResponse) GetPages() GetPageConflictLatestPagesPageQuery {
+	return v.Pages
+}
+
 // GetPagePagesPageQuery includes the requested fields of the GraphQL type PageQuery.
 type GetPagePagesPageQuery struct {
 	Single GetPagePagesPageQuerySinglePage `json:"single"`
@@ -2013,6 +2139,34 @@ func (v *GetSiteConfigSiteSiteQueryConfigSiteConfig) GetUploadForceDownload() bo
 	return v.UploadForceDownload
 }
 
+// GetSystemInfoResponse is returned by GetSystemInfo on success.
+type GetSystemInfoResponse struct {
+	System GetSystemInfoSystemSystemQuery `json:"system"`
+}
+
+// GetSystem returns GetSystemInfoResponse.System, and is useful for accessing the field via an interface.
+func (v *GetSystemInfoResponse) GetSystem() GetSystemInfoSystemSystemQuery { return v.System }
+
+// GetSystemInfoSystemSystemQuery includes the requested fields of the GraphQL type SystemQuery.
+type GetSystemInfoSystemSystemQuery struct {
+	Info GetSystemInfoSystemSystemQueryInfoSystemInfo `json:"info"`
+}
+
+// GetInfo returns GetSystemInfoSystemSystemQuery.Info, and is useful for accessing the field via an interface.
+func (v *GetSystemInfoSystemSystemQuery) GetInfo() GetSystemInfoSystemSystemQueryInfoSystemInfo {
+	return v.Info
+}
+
+// GetSystemInfoSystemSystemQueryInfoSystemInfo includes the requested fields of the GraphQL type SystemInfo.
+type GetSystemInfoSystemSystemQueryInfoSystemInfo struct {
+	CurrentVersion string `json:"currentVersion"`
+}
+
+// GetCurrentVersion returns GetSystemInfoSystemSystemQueryInfoSystemInfo.CurrentVersion, and is useful for accessing the field via an interface.
+func (v *GetSystemInfoSystemSystemQueryInfoSystemInfo) GetCurrentVersion() string {
+	return v.CurrentVersion
+}
+
 // GetThemeConfigResponse is returned by GetThemeConfig on success.
 type GetThemeConfigResponse struct {
 	Theming GetThemeConfigThemingThemingQuery `json:"theming"`
@@ -2274,6 +2428,66 @@ type ListGroupsResponse struct {
 // GetGroups returns ListGroupsResponse.Groups, and is useful for accessing the field via an interface.
 func (v *ListGroupsResponse) GetGroups() ListGroupsGroupsGroupQuery { return v.Groups }
 
+// ListPagesPagesPageQuery includes the requested fields of the GraphQL type PageQuery.
+type ListPagesPagesPageQuery struct {
+	List []ListPagesPagesPageQueryListPageListItem `json:"list"`
+}
+
+// GetList returns ListPagesPagesPageQuery.List, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQuery) GetList() []ListPagesPagesPageQueryListPageListItem { return v.List }
+
+// ListPagesPagesPageQueryListPageListItem includes the requested fields of the GraphQL type PageListItem.
+type ListPagesPagesPageQueryListPageListItem struct {
+	Id          int      `json:"id"`
+	Path        string   `json:"path"`
+	Locale      string   `json:"locale"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	IsPublished bool     `json:"isPublished"`
+	IsPrivate   bool     `json:"isPrivate"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+	Tags        []string `json:"tags"`
+}
+
+// GetId returns ListPagesPagesPageQueryListPageListItem.Id, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetId() int { return v.Id }
+
+// GetPath returns ListPagesPagesPageQueryListPageListItem.Path, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetPath() string { return v.Path }
+
+// GetLocale returns ListPagesPagesPageQueryListPageListItem.Locale, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetLocale() string { return v.Locale }
+
+// GetTitle returns ListPagesPagesPageQueryListPageListItem.Title, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetTitle() string { return v.Title }
+
+// GetDescription returns ListPagesPagesPageQueryListPageListItem.Description, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetDescription() string { return v.Description }
+
+// GetIsPublished returns ListPagesPagesPageQueryListPageListItem.IsPublished, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetIsPublished() bool { return v.IsPublished }
+
+// GetIsPrivate returns ListPagesPagesPageQueryListPageListItem.IsPrivate, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetIsPrivate() bool { return v.IsPrivate }
+
+// GetCreatedAt returns ListPagesPagesPageQueryListPageListItem.CreatedAt, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetCreatedAt() string { return v.CreatedAt }
+
+// GetUpdatedAt returns ListPagesPagesPageQueryListPageListItem.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetUpdatedAt() string { return v.UpdatedAt }
+
+// GetTags returns ListPagesPagesPageQueryListPageListItem.Tags, and is useful for accessing the field via an interface.
+func (v *ListPagesPagesPageQueryListPageListItem) GetTags() []string { return v.Tags }
+
+// ListPagesResponse is returned by ListPages on success.
+type ListPagesResponse struct {
+	Pages ListPagesPagesPageQuery `json:"pages"`
+}
+
+// GetPages returns ListPagesResponse.Pages, and is useful for accessing the field via an interface.
+func (v *ListPagesResponse) GetPages() ListPagesPagesPageQuery { return v.Pages }
+
 // LoginAuthenticationAuthenticationMutation includes the requested fields of the GraphQL type AuthenticationMutation.
 type LoginAuthenticationAuthenticationMutation struct {
 	Login LoginAuthenticationAuthenticationMutationLoginAuthenticationLoginResponse `json:"login"`
@@ -2374,6 +2588,173 @@ func (v *LoginResponse) GetAuthentication() LoginAuthenticationAuthenticationMut
 	return v.Authentication
 }
 
+// LoginTFAAuthenticationAuthenticationMutation includes the requested fields of the GraphQL type AuthenticationMutation.
+type LoginTFAAuthenticationAuthenticationMutation struct {
+	LoginTFA LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse `json:"loginTFA"`
+}
+
+// GetLoginTFA returns LoginTFAAuthenticationAuthenticationMutation.LoginTFA, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutation) GetLoginTFA() LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse {
+	return v.LoginTFA
+}
+
+// LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse includes the requested fields of the GraphQL type AuthenticationLoginResponse.
+type LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse struct {
+	ResponseResult    LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus `json:"responseResult"`
+	Jwt               string                                                                                                      `json:"jwt"`
+	MustChangePwd     bool                                                                                                        `json:"mustChangePwd"`
+	MustProvideTFA    bool                                                                                                        `json:"mustProvideTFA"`
+	MustSetupTFA      bool                                                                                                        `json:"mustSetupTFA"`
+	ContinuationToken string                                                                                                      `json:"continuationToken"`
+	Redirect          string                                                                                                      `json:"redirect"`
+}
+
+// GetResponseResult returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.ResponseResult, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetResponseResult() LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus {
+	return v.ResponseResult
+}
+
+// GetJwt returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.Jwt, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetJwt() string {
+	return v.Jwt
+}
+
+// GetMustChangePwd returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.MustChangePwd, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetMustChangePwd() bool {
+	return v.MustChangePwd
+}
+
+// GetMustProvideTFA returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.MustProvideTFA, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetMustProvideTFA() bool {
+	return v.MustProvideTFA
+}
+
+// GetMustSetupTFA returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.MustSetupTFA, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetMustSetupTFA() bool {
+	return v.MustSetupTFA
+}
+
+// GetContinuationToken returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.ContinuationToken, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetContinuationToken() string {
+	return v.ContinuationToken
+}
+
+// GetRedirect returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse.Redirect, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponse) GetRedirect() string {
+	return v.Redirect
+}
+
+// LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus includes the requested fields of the GraphQL type ResponseStatus.
+type LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus struct {
+	Succeeded bool   `json:"succeeded"`
+	ErrorCode int    `json:"errorCode"`
+	Slug      string `json:"slug"`
+	Message   string `json:"message"`
+}
+
+// GetSucceeded returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus.Succeeded, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus) GetSucceeded() bool {
+	return v.Succeeded
+}
+
+// GetErrorCode returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus.ErrorCode, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus) GetErrorCode() int {
+	return v.ErrorCode
+}
+
+// GetSlug returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus.Slug, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus) GetSlug() string {
+	return v.Slug
+}
+
+// GetMessage returns LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus.Message, and is useful for accessing the field via an interface.
+func (v *LoginTFAAuthenticationAuthenticationMutationLoginTFAAuthenticationLoginResponseResponseResultResponseStatus) GetMessage() string {
+	return v.Message
+}
+
+// LoginTFAResponse is returned by LoginTFA on success.
+type LoginTFAResponse struct {
+	Authentication LoginTFAAuthenticationAuthenticationMutation `json:"authentication"`
+}
+
+// GetAuthentication returns LoginTFAResponse.Authentication, and is useful for accessing the field via an interface.
+func (v *LoginTFAResponse) GetAuthentication() LoginTFAAuthenticationAuthenticationMutation {
+	return v.Authentication
+}
+
+// MovePagePagesPageMutation includes the requested fields of the GraphQL type PageMutation.
+type MovePagePagesPageMutation struct {
+	Move MovePagePagesPageMutationMoveDefaultResponse `json:"move"`
+}
+
+// GetMove returns MovePagePagesPageMutation.Move, and is useful for accessing the field via an interface.
+func (v *MovePagePagesPageMutation) GetMove() MovePagePagesPageMutationMoveDefaultResponse {
+	return v.Move
+}
+
+// MovePagePagesPageMutationMoveDefaultResponse includes the requested fields of the GraphQL type DefaultResponse.
+type MovePagePagesPageMutationMoveDefaultResponse struct {
+	ResponseResult MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus `json:"responseResult"`
+}
+
+// GetResponseResult returns MovePagePagesPageMutationMoveDefaultResponse.ResponseResult, and is useful for accessing the field via an interface.
+func (v *MovePagePagesPageMutationMoveDefaultResponse) GetResponseResult() MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus {
+	return v.ResponseResult
+}
+
+// MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus includes the requested fields of the GraphQL type ResponseStatus.
+type MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus struct {
+	Succeeded bool   `json:"succeeded"`
+	ErrorCode int    `json:"errorCode"`
+	Slug      string `json:"slug"`
+	Message   string `json:"message"`
+}
+
+// GetSucceeded returns MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus.Succeeded, and is useful for accessing the field via an interface.
+func (v *MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus) GetSucceeded() bool {
+	return v.Succeeded
+}
+
+// GetErrorCode returns MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus.ErrorCode, and is useful for accessing the field via an interface.
+func (v *MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus) GetErrorCode() int {
+	return v.ErrorCode
+}
+
+// GetSlug returns MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus.Slug, and is useful for accessing the field via an interface.
+func (v *MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus) GetSlug() string {
+	return v.Slug
+}
+
+// GetMessage returns MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus.Message, and is useful for accessing the field via an interface.
+func (v *MovePagePagesPageMutationMoveDefaultResponseResponseResultResponseStatus) GetMessage() string {
+	return v.Message
+}
+
+// MovePageResponse is returned by MovePage on success.
+type MovePageResponse struct {
+	Pages MovePagePagesPageMutation `json:"pages"`
+}
+
+// GetPages returns MovePageResponse.Pages, and is useful for accessing the field via an interface.
+func (v *MovePageResponse) GetPages() MovePagePagesPageMutation { return v.Pages }
+
+type PageOrderBy string
+
+const (
+	PageOrderByCreated PageOrderBy = "CREATED"
+	PageOrderById      PageOrderBy = "ID"
+	PageOrderByPath    PageOrderBy = "PATH"
+	PageOrderByTitle   PageOrderBy = "TITLE"
+	PageOrderByUpdated PageOrderBy = "UPDATED"
+)
+
+type PageOrderByDirection string
+
+const (
+	PageOrderByDirectionAsc  PageOrderByDirection = "ASC"
+	PageOrderByDirectionDesc PageOrderByDirection = "DESC"
+)
+
 type PageRuleInput struct {
 	Id      string        `json:"id"`
 	Deny    bool          `json:"deny"`
@@ -3225,6 +3606,30 @@ func (v *UpdateSiteConfigSiteSiteMutationUpdateConfigDefaultResponseResponseResu
 	return v.Message
 }
 
+// __CheckPageConflictsInput is used internally by genqlient
+type __CheckPageConflictsInput struct {
+	Id           int    `json:"id"`
+	CheckoutDate string `json:"checkoutDate"`
+}
+
+// GetId returns __CheckPageConflictsInput.Id, and is useful for accessing the field via an interface.
+func (v *__CheckPageConflictsInput) GetId() int { return v.Id }
+
+// GetCheckoutDate returns __CheckPageConflictsInput.CheckoutDate, and is useful for accessing the field via an interface.
+func (v *__CheckPageConflictsInput) GetCheckoutDate() string { return v.CheckoutDate }
+
+// __ConvertPageInput is used internally by genqlient
+type __ConvertPageInput struct {
+	Id     int    `json:"id"`
+	Editor string `json:"editor"`
+}
+
+// GetId returns __ConvertPageInput.Id, and is useful for accessing the field via an interface.
+func (v *__ConvertPageInput) GetId() int { return v.Id }
+
+// GetEditor returns __ConvertPageInput.Editor, and is useful for accessing the field via an interface.
+func (v *__ConvertPageInput) GetEditor() string { return v.Editor }
+
 // __CreateApiKeyInput is used internally by genqlient
 type __CreateApiKeyInput struct {
 	Name       string `json:"name"`
@@ -3373,6 +3778,14 @@ func (v *__GetPageByPathInput) GetPath() string { return v.Path }
 // GetLocale returns __GetPageByPathInput.Locale, and is useful for accessing the field via an interface.
 func (v *__GetPageByPathInput) GetLocale() string { return v.Locale }
 
+// __GetPageConflictLatestInput is used internally by genqlient
+type __GetPageConflictLatestInput struct {
+	Id int `json:"id"`
+}
+
+// GetId returns __GetPageConflictLatestInput.Id, and is useful for accessing the field via an interface.
+func (v *__GetPageConflictLatestInput) GetId() int { return v.Id }
+
 // __GetPageInput is used internally by genqlient
 type __GetPageInput struct {
 	Id int `json:"id"`
@@ -3441,6 +3854,38 @@ func (v *__ListGroupsInput) GetFilter() string { return v.Filter }
 // GetOrderBy returns __ListGroupsInput.OrderBy, and is useful for accessing the field via an interface.
 func (v *__ListGroupsInput) GetOrderBy() string { return v.OrderBy }
 
+// __ListPagesInput is used internally by genqlient
+type __ListPagesInput struct {
+	Limit            int                  `json:"limit,omitempty"`
+	OrderBy          PageOrderBy          `json:"orderBy,omitempty"`
+	OrderByDirection PageOrderByDirection `json:"orderByDirection,omitempty"`
+	Tags             []string             `json:"tags,omitempty"`
+	Locale           string               `json:"locale,omitempty"`
+	CreatorId        int                  `json:"creatorId,omitempty"`
+	AuthorId         int                  `json:"authorId,omitempty"`
+}
+
+// GetLimit returns __ListPagesInput.Limit, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetLimit() int { return v.Limit }
+
+// GetOrderBy returns __ListPagesInput.OrderBy, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetOrderBy() PageOrderBy { return v.OrderBy }
+
+// GetOrderByDirection returns __ListPagesInput.OrderByDirection, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetOrderByDirection() PageOrderByDirection { return v.OrderByDirection }
+
+// GetTags returns __ListPagesInput.Tags, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetTags() []string { return v.Tags }
+
+// GetLocale returns __ListPagesInput.Locale, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetLocale() string { return v.Locale }
+
+// GetCreatorId returns __ListPagesInput.CreatorId, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetCreatorId() int { return v.CreatorId }
+
+// GetAuthorId returns __ListPagesInput.AuthorId, and is useful for accessing the field via an interface.
+func (v *__ListPagesInput) GetAuthorId() int { return v.AuthorId }
+
 // __LoginInput is used internally by genqlient
 type __LoginInput struct {
 	Username string `json:"username"`
@@ -3457,6 +3902,34 @@ func (v *__LoginInput) GetPassword() string { return v.Password }
 // GetStrategy returns __LoginInput.Strategy, and is useful for accessing the field via an interface.
 func (v *__LoginInput) GetStrategy() string { return v.Strategy }
 
+// __LoginTFAInput is used internally by genqlient
+type __LoginTFAInput struct {
+	ContinuationToken string `json:"continuationToken"`
+	SecurityCode      string `json:"securityCode"`
+}
+
+// GetContinuationToken returns __LoginTFAInput.ContinuationToken, and is useful for accessing the field via an interface.
+func (v *__LoginTFAInput) GetContinuationToken() string { return v.ContinuationToken }
+
+// GetSecurityCode returns __LoginTFAInput.SecurityCode, and is useful for accessing the field via an interface.
+func (v *__LoginTFAInput) GetSecurityCode() string { return v.SecurityCode }
+
+// __MovePageInput is used internally by genqlient
+type __MovePageInput struct {
+	Id                int    `json:"id"`
+	DestinationPath   string `json:"destinationPath"`
+	DestinationLocale string `json:"destinationLocale"`
+}
+
+// GetId returns __MovePageInput.Id, and is useful for accessing the field via an interface.
+func (v *__MovePageInput) GetId() int { return v.Id }
+
+// GetDestinationPath returns __MovePageInput.DestinationPath, and is useful for accessing the field via an interface.
+func (v *__MovePageInput) GetDestinationPath() string { return v.DestinationPath }
+
+// GetDestinationLocale returns __MovePageInput.DestinationLocale, and is useful for accessing the field via an interface.
+func (v *__MovePageInput) GetDestinationLocale() string { return v.DestinationLocale }
+
 // __RevokeApiKeyInput is used internally by genqlient
 type __RevokeApiKeyInput struct {
 	Id int `json:"id"`
@@ -3801,6 +4274,87 @@ func (v *__UpdateSiteConfigInput) GetUploadScanSVG() bool { return v.UploadScanS
 // GetUploadForceDownload returns __UpdateSiteConfigInput.UploadForceDownload, and is useful for accessing the field via an interface.
 func (v *__UpdateSiteConfigInput) GetUploadForceDownload() bool { return v.UploadForceDownload }
 
+// The query or mutation executed by CheckPageConflicts.
+const CheckPageConflicts_Operation = `
+query CheckPageConflicts ($id: Int!, $checkoutDate: Date!) {
+	pages {
+		checkConflicts(id: $id, checkoutDate: $checkoutDate)
+	}
+}
+`
+
+func CheckPageConflicts(
+	ctx context.Context,
+	client graphql.Client,
+	id int,
+	checkoutDate string,
+) (*CheckPageConflictsResponse, error) {
+	req := &graphql.Request{
+		OpName: "CheckPageConflicts",
+		Query:  CheckPageConflicts_Operation,
+		Variables: &__CheckPageConflictsInput{
+			Id:           id,
+			CheckoutDate: checkoutDate,
+		},
+	}
+	var err error
+
+	var data CheckPageConflictsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by ConvertPage.
+const ConvertPage_Operation = `
+mutation ConvertPage ($id: Int!, $editor: String!) {
+	pages {
+		convert(id: $id, editor: $editor) {
+			responseResult {
+				succeeded
+				errorCode
+				slug
+				message
+			}
+		}
+	}
+}
+`
+
+func ConvertPage(
+	ctx context.Context,
+	client graphql.Client,
+	id int,
+	editor string,
+) (*ConvertPageResponse, error) {
+	req := &graphql.Request{
+		OpName: "ConvertPage",
+		Query:  ConvertPage_Operation,
+		Variables: &__ConvertPageInput{
+			Id:     id,
+			Editor: editor,
+		},
+	}
+	var err error
+
+	var data ConvertPageResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by CreateApiKey.
 const CreateApiKey_Operation = `
 mutation CreateApiKey ($name: String!, $expiration: String!, $fullAccess: Boolean!, $group: Int) {
@@ -4630,6 +5184,47 @@ func GetPageByPath(
 	return &data, err
 }
 
+// The query or mutation executed by GetPageConflictLatest.
+const GetPageConflictLatest_Operation = `
+query GetPageConflictLatest ($id: Int!) {
+	pages {
+		conflictLatest(id: $id) {
+			id
+			authorId
+			authorName
+			content
+			updatedAt
+		}
+	}
+}
+`
+
+func GetPageConflictLatest(
+	ctx context.Context,
+	client graphql.Client,
+	id int,
+) (*GetPageConflictLatestResponse, error) {
+	req := &graphql.Request{
+		OpName: "GetPageConflictLatest",
+		Query:  GetPageConflictLatest_Operation,
+		Variables: &__GetPageConflictLatestInput{
+			Id: id,
+		},
+	}
+	var err error
+
+	var data GetPageConflictLatestResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by GetRenderers.
 const GetRenderers_Operation = `
 query GetRenderers (# @genqlient(omitempty: true)
@@ -4806,6 +5401,39 @@ func GetSiteConfig(
 	return &data, err
 }
 
+// The query or mutation executed by GetSystemInfo.
+const GetSystemInfo_Operation = `
+query GetSystemInfo {
+	system {
+		info {
+			currentVersion
+		}
+	}
+}
+`
+
+func GetSystemInfo(
+	ctx context.Context,
+	client graphql.Client,
+) (*GetSystemInfoResponse, error) {
+	req := &graphql.Request{
+		OpName: "GetSystemInfo",
+		Query:  GetSystemInfo_Operation,
+	}
+	var err error
+
+	var data GetSystemInfoResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by GetThemeConfig.
 const GetThemeConfig_Operation = `
 query GetThemeConfig {
@@ -5012,6 +5640,71 @@ func ListGroups(
 	return &data, err
 }
 
+// The query or mutation executed by ListPages.
+const ListPages_Operation = `
+query ListPages (# @genqlient(omitempty: true)
+$limit: Int, # @genqlient(omitempty: true)
+$orderBy: PageOrderBy, # @genqlient(omitempty: true)
+$orderByDirection: PageOrderByDirection, # @genqlient(omitempty: true)
+$tags: [String!], # @genqlient(omitempty: true)
+$locale: String, # @genqlient(omitempty: true)
+$creatorId: Int, # @genqlient(omitempty: true)
+$authorId: Int) {
+	pages {
+		list(limit: $limit, orderBy: $orderBy, orderByDirection: $orderByDirection, tags: $tags, locale: $locale, creatorId: $creatorId, authorId: $authorId) {
+			id
+			path
+			locale
+			title
+			description
+			isPublished
+			isPrivate
+			createdAt
+			updatedAt
+			tags
+		}
+	}
+}
+`
+
+func ListPages(
+	ctx context.Context,
+	client graphql.Client,
+	limit int,
+	orderBy PageOrderBy,
+	orderByDirection PageOrderByDirection,
+	tags []string,
+	locale string,
+	creatorId int,
+	authorId int,
+) (*ListPagesResponse, error) {
+	req := &graphql.Request{
+		OpName: "ListPages",
+		Query:  ListPages_Operation,
+		Variables: &__ListPagesInput{
+			Limit:            limit,
+			OrderBy:          orderBy,
+			OrderByDirection: orderByDirection,
+			Tags:             tags,
+			Locale:           locale,
+			CreatorId:        creatorId,
+			AuthorId:         authorId,
+		},
+	}
+	var err error
+
+	var data ListPagesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by Login.
 const Login_Operation = `
 mutation Login ($username: String!, $password: String!, $strategy: String!) {
@@ -5065,6 +5758,102 @@ func Login(
 	return &data, err
 }
 
+// The query or mutation executed by LoginTFA.
+const LoginTFA_Operation = `
+mutation LoginTFA ($continuationToken: String!, $securityCode: String!) {
+	authentication {
+		loginTFA(continuationToken: $continuationToken, securityCode: $securityCode) {
+			responseResult {
+				succeeded
+				errorCode
+				slug
+				message
+			}
+			jwt
+			mustChangePwd
+			mustProvideTFA
+			mustSetupTFA
+			continuationToken
+			redirect
+		}
+	}
+}
+`
+
+func LoginTFA(
+	ctx context.Context,
+	client graphql.Client,
+	continuationToken string,
+	securityCode string,
+) (*LoginTFAResponse, error) {
+	req := &graphql.Request{
+		OpName: "LoginTFA",
+		Query:  LoginTFA_Operation,
+		Variables: &__LoginTFAInput{
+			ContinuationToken: continuationToken,
+			SecurityCode:      securityCode,
+		},
+	}
+	var err error
+
+	var data LoginTFAResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by MovePage.
+const MovePage_Operation = `
+mutation MovePage ($id: Int!, $destinationPath: String!, $destinationLocale: String!) {
+	pages {
+		move(id: $id, destinationPath: $destinationPath, destinationLocale: $destinationLocale) {
+			responseResult {
+				succeeded
+				errorCode
+				slug
+				message
+			}
+		}
+	}
+}
+`
+
+func MovePage(
+	ctx context.Context,
+	client graphql.Client,
+	id int,
+	destinationPath string,
+	destinationLocale string,
+) (*MovePageResponse, error) {
+	req := &graphql.Request{
+		OpName: "MovePage",
+		Query:  MovePage_Operation,
+		Variables: &__MovePageInput{
+			Id:                id,
+			DestinationPath:   destinationPath,
+			DestinationLocale: destinationLocale,
+		},
+	}
+	var err error
+
+	var data MovePageResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by RebuildSearchIndex.
 const RebuildSearchIndex_Operation = `
 mutation RebuildSearchIndex {
@@ -0,0 +1,10 @@
+package wikijs
+
+import (
+	_ "embed"
+)
+
+// Schema is the wiki.js GraphQL schema the client is generated from.
+//
+//go:embed schema.graphql
+var Schema string
@@ -0,0 +1,179 @@
+package wikijstest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// strategyInfo describes the authentication modules the fake knows about.
+var strategyInfo = map[string]map[string]interface{}{
+	"local":    {"key": "local", "title": "Local", "description": "Built-in authentication for Wiki.js", "isAvailable": true, "useForm": true, "usernameType": "email", "logo": "", "color": "primary", "website": "https://wiki.js.org", "icon": "/_assets/svg/authentication/wikijs.svg"},
+	"github":   {"key": "github", "title": "GitHub", "description": "GitHub is a web-based hosting service for version control using Git.", "isAvailable": true, "useForm": false, "usernameType": "", "logo": "", "color": "grey darken-3", "website": "https://github.com", "icon": "/_assets/svg/authentication/github.svg"},
+	"oidc":     {"key": "oidc", "title": "Generic OpenID Connect / OAuth2", "description": "OpenID Connect is an identity layer on top of the OAuth 2.0 protocol.", "isAvailable": true, "useForm": false, "usernameType": "", "logo": "", "color": "blue-grey darken-2", "website": "http://openid.net/connect/", "icon": "/_assets/svg/authentication/oidc.svg"},
+	"keycloak": {"key": "keycloak", "title": "Keycloak", "description": "Keycloak is an open source software product to allow single sign-on with Identity Management and Access Management.", "isAvailable": true, "useForm": false, "usernameType": "", "logo": "", "color": "blue-grey darken-2", "website": "https://www.keycloak.org/", "icon": "/_assets/svg/authentication/keycloak.svg"},
+}
+
+func (s *Server) authenticationQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"apiKeys": resolver(func(args map[string]interface{}) (interface{}, error) {
+			keys := append([]*ApiKey(nil), s.store.ApiKeys...)
+			sort.SliceStable(keys, func(i, j int) bool {
+				if keys[i].IsRevoked != keys[j].IsRevoked {
+					return !keys[i].IsRevoked
+				}
+				return keys[i].Name < keys[j].Name
+			})
+			return keys, nil
+		}),
+		"apiState": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.store.ApiEnabled, nil
+		}),
+		"activeStrategies": resolver(func(args map[string]interface{}) (interface{}, error) {
+			var out []interface{}
+			for _, st := range s.store.Strategies {
+				if boolArg(args, "enabledOnly") && !st.IsEnabled {
+					continue
+				}
+				v := jsonValue(st).(map[string]interface{})
+				v["strategy"] = strategyInfo[st.StrategyKey]
+				v["config"] = configValues(st.Config)
+				out = append(out, v)
+			}
+			return out, nil
+		}),
+	}
+}
+
+func (s *Server) authenticationMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"createApiKey": resolver(func(args map[string]interface{}) (interface{}, error) {
+			expiration, err := parseExpiration(stringArg(args, "expiration"))
+			if err != nil {
+				return map[string]interface{}{"responseResult": responseResult(errInputInvalid)}, nil
+			}
+
+			now := timestamp()
+			id := s.store.nextID()
+			key := newToken(map[string]interface{}{"api": id, "grp": intArg(args, "group"), "exp": time.Now().Add(expiration).Unix()})
+			s.store.ApiKeys = append(s.store.ApiKeys, &ApiKey{
+				Id:         id,
+				Name:       stringArg(args, "name"),
+				KeyShort:   "..." + key[len(key)-20:],
+				Expiration: time.Now().Add(expiration).UTC().Format(time.RFC3339),
+				CreatedAt:  now,
+				UpdatedAt:  now,
+				Key:        key,
+				FullAccess: boolArg(args, "fullAccess"),
+				Group:      intArg(args, "group"),
+			})
+
+			return map[string]interface{}{"responseResult": responseResult(nil), "key": key}, nil
+		}),
+		"revokeApiKey": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, k := range s.store.ApiKeys {
+				if k.Id == intArg(args, "id") {
+					k.IsRevoked = true
+					k.UpdatedAt = timestamp()
+				}
+			}
+			return defaultResponse(nil), nil
+		}),
+		"setApiState": resolver(func(args map[string]interface{}) (interface{}, error) {
+			s.store.ApiEnabled = boolArg(args, "enabled")
+			return defaultResponse(nil), nil
+		}),
+		"updateStrategies": resolver(func(args map[string]interface{}) (interface{}, error) {
+			var strategies []*AuthStrategy
+			for _, in := range objectsArg(args, "strategies") {
+				if _, ok := strategyInfo[stringArg(in, "strategyKey")]; !ok {
+					return defaultResponse(errAuthProviderInvalid), nil
+				}
+				strategies = append(strategies, &AuthStrategy{
+					Key:              stringArg(in, "key"),
+					StrategyKey:      stringArg(in, "strategyKey"),
+					DisplayName:      stringArg(in, "displayName"),
+					Order:            intArg(in, "order"),
+					IsEnabled:        boolArg(in, "isEnabled"),
+					Config:           configInput(in),
+					SelfRegistration: boolArg(in, "selfRegistration"),
+					DomainWhitelist:  stringsArg(in, "domainWhitelist"),
+					AutoEnrollGroups: intsArg(in, "autoEnrollGroups"),
+				})
+			}
+			sort.SliceStable(strategies, func(i, j int) bool { return strategies[i].Order < strategies[j].Order })
+			s.store.Strategies = strategies
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) loginMutation(args map[string]interface{}) (interface{}, error) {
+	if stringArg(args, "strategy") != "local" {
+		return map[string]interface{}{"responseResult": responseResult(errAuthProviderInvalid)}, nil
+	}
+
+	user := s.store.user(stringArg(args, "username"))
+	if user == nil || user.IsSystem || user.Password != stringArg(args, "password") {
+		return map[string]interface{}{"responseResult": responseResult(errAuthLoginFailed)}, nil
+	}
+
+	if user.TOTPSecret != "" {
+		token := randomHex(16)
+		s.pendingTFA[token] = user
+		return map[string]interface{}{
+			"responseResult":    responseResult(nil),
+			"mustProvideTFA":    true,
+			"continuationToken": token,
+		}, nil
+	}
+
+	return s.loginResponse(user), nil
+}
+
+func (s *Server) loginTFAMutation(args map[string]interface{}) (interface{}, error) {
+	user, ok := s.pendingTFA[stringArg(args, "continuationToken")]
+	if !ok {
+		return map[string]interface{}{"responseResult": responseResult(errAuthTFAInvalid)}, nil
+	}
+	if !validTOTP(user.TOTPSecret, stringArg(args, "securityCode")) {
+		return map[string]interface{}{"responseResult": responseResult(errAuthTFAFailed)}, nil
+	}
+	delete(s.pendingTFA, stringArg(args, "continuationToken"))
+
+	return s.loginResponse(user), nil
+}
+
+func (s *Server) loginResponse(user *User) map[string]interface{} {
+	user.LastLoginAt = timestamp()
+
+	return map[string]interface{}{
+		"responseResult": responseResult(nil),
+		"jwt":            s.login(user),
+		"redirect":       "/",
+	}
+}
+
+// parseExpiration parses the durations wiki.js accepts for API keys, e.g.
+// "30d" or "1y".
+func parseExpiration(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"h": time.Hour,
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+		"y": 365 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, err
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid expiration %q", s)
+}
@@ -0,0 +1,148 @@
+package wikijstest
+
+func (s *Server) siteQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"config": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.store.SiteConfig, nil
+		}),
+	}
+}
+
+func (s *Server) siteMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"updateConfig": resolver(func(args map[string]interface{}) (interface{}, error) {
+			mergeConfig(s.store.SiteConfig, args)
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) themingQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"themes": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.store.Themes, nil
+		}),
+		"config": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.store.ThemeConfig, nil
+		}),
+	}
+}
+
+func (s *Server) themingMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"setConfig": resolver(func(args map[string]interface{}) (interface{}, error) {
+			mergeConfig(s.store.ThemeConfig, args)
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) localizationQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"locales": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.store.Locales, nil
+		}),
+		"config": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.store.Localization, nil
+		}),
+	}
+}
+
+func (s *Server) localizationMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"downloadLocale": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, l := range s.store.Locales {
+				if l.Code == stringArg(args, "locale") {
+					l.IsInstalled = true
+					l.InstallDate = timestamp()
+					return defaultResponse(nil), nil
+				}
+			}
+			return defaultResponse(errLocaleGeneric), nil
+		}),
+		"updateLocale": resolver(func(args map[string]interface{}) (interface{}, error) {
+			mergeConfig(s.store.Localization, args)
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) renderingQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"renderers": resolver(func(args map[string]interface{}) (interface{}, error) {
+			out := make([]interface{}, 0, len(s.store.Renderers))
+			for _, r := range s.store.Renderers {
+				v := jsonValue(r).(map[string]interface{})
+				v["config"] = configValues(r.Config)
+				out = append(out, v)
+			}
+			return out, nil
+		}),
+	}
+}
+
+func (s *Server) renderingMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"updateRenderers": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, in := range objectsArg(args, "renderers") {
+				for _, r := range s.store.Renderers {
+					if r.Key == stringArg(in, "key") {
+						r.IsEnabled = boolArg(in, "isEnabled")
+						r.Config = configInput(in)
+					}
+				}
+			}
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) searchQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"searchEngines": resolver(func(args map[string]interface{}) (interface{}, error) {
+			out := make([]interface{}, 0, len(s.store.SearchEngines))
+			for _, e := range s.store.SearchEngines {
+				v := jsonValue(e).(map[string]interface{})
+				v["config"] = configValues(e.Config)
+				out = append(out, v)
+			}
+			return out, nil
+		}),
+	}
+}
+
+func (s *Server) searchMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"updateSearchEngines": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, in := range objectsArg(args, "engines") {
+				for _, e := range s.store.SearchEngines {
+					if e.Key == stringArg(in, "key") {
+						e.IsEnabled = boolArg(in, "isEnabled")
+						e.Config = configInput(in)
+					}
+				}
+			}
+			return defaultResponse(nil), nil
+		}),
+		"rebuildIndex": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) systemQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"info": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{"currentVersion": s.store.Version}, nil
+		}),
+	}
+}
+
+// mergeConfig sets all arguments that were passed on config.
+func mergeConfig(config map[string]interface{}, args map[string]interface{}) {
+	for k, v := range args {
+		if v != nil {
+			config[k] = v
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package wikijstest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// resolver computes the value of a field from its arguments. Objects are
+// map[string]interface{} holding plain values or resolvers, any other value
+// is converted through its JSON encoding, so structs need json tags matching
+// the GraphQL field names.
+type resolver func(args map[string]interface{}) (interface{}, error)
+
+// wikiError is an error of wiki.js with its error code, see
+// server/helpers/error.js in the wiki.js sources.
+type wikiError struct {
+	code    int
+	slug    string
+	message string
+}
+
+func (e *wikiError) Error() string {
+	return e.message
+}
+
+var (
+	errAuthLoginFailed      = &wikiError{1002, "AuthLoginFailed", "Invalid email / username or password."}
+	errAuthProviderInvalid  = &wikiError{1003, "AuthProviderInvalid", "Invalid authentication provider."}
+	errAuthTFAFailed        = &wikiError{1005, "AuthTFAFailed", "Incorrect TFA Security Code."}
+	errAuthTFAInvalid       = &wikiError{1006, "AuthTFAInvalid", "Invalid TFA Security Code or Login Token."}
+	errInputInvalid         = &wikiError{1012, "InputInvalid", "Input data is invalid."}
+	errLocaleGeneric        = &wikiError{5001, "LocaleGenericError", "An unexpected error occured during locale operation."}
+	errPageDuplicateCreate  = &wikiError{6002, "PageDuplicateCreate", "Cannot create this page because an entry already exists at the same path."}
+	errPageNotFound         = &wikiError{6003, "PageNotFound", "This page does not exist."}
+	errPageEmptyContent     = &wikiError{6004, "PageEmptyContent", "Page content cannot be empty."}
+	errPageIllegalPath      = &wikiError{6005, "PageIllegalPath", "Page path cannot contains illegal characters."}
+	errPagePathCollision    = &wikiError{6006, "PagePathCollision", "Destination page path already exists."}
+	errSystemGroupProtected = errors.New("Cannot delete this group.")
+	errForbidden            = errors.New("Forbidden")
+)
+
+// executor runs one GraphQL operation.
+type executor struct {
+	vars   map[string]interface{}
+	errors gqlerror.List
+}
+
+func (e *executor) selectionSet(set ast.SelectionSet, obj map[string]interface{}, path ast.Path) map[string]interface{} {
+	out := map[string]interface{}{}
+	e.collect(set, obj, path, out)
+	return out
+}
+
+func (e *executor) collect(set ast.SelectionSet, obj map[string]interface{}, path ast.Path, out map[string]interface{}) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			key := sel.Alias
+			if key == "" {
+				key = sel.Name
+			}
+			out[key] = e.field(sel, obj, append(path, ast.PathName(key)))
+		case *ast.InlineFragment:
+			e.collect(sel.SelectionSet, obj, path, out)
+		case *ast.FragmentSpread:
+			e.collect(sel.Definition.SelectionSet, obj, path, out)
+		}
+	}
+}
+
+func (e *executor) field(f *ast.Field, obj map[string]interface{}, path ast.Path) interface{} {
+	if f.Name == "__typename" {
+		return f.ObjectDefinition.Name
+	}
+
+	value := obj[f.Name]
+	if r, ok := value.(resolver); ok {
+		var err error
+		value, err = r(f.ArgumentMap(e.vars))
+		if err != nil {
+			e.addError(err, path)
+			return nil
+		}
+	}
+
+	return e.complete(f.SelectionSet, value, path)
+}
+
+// complete resolves the selection set on objects and lists of objects.
+func (e *executor) complete(set ast.SelectionSet, value interface{}, path ast.Path) interface{} {
+	if len(set) == 0 || value == nil {
+		return value
+	}
+
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		value = jsonValue(value)
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return e.selectionSet(set, value, path)
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, item := range value {
+			out[i] = e.complete(set, item, append(path, ast.PathIndex(i)))
+		}
+		return out
+	}
+
+	return nil
+}
+
+func (e *executor) addError(err error, path ast.Path) {
+	gqlErr := &gqlerror.Error{Message: err.Error(), Path: append(ast.Path(nil), path...)}
+
+	// apollo passes the properties of wiki.js errors on as exception extension
+	var wErr *wikiError
+	if errors.As(err, &wErr) {
+		gqlErr.Extensions = map[string]interface{}{
+			"code": "INTERNAL_SERVER_ERROR",
+			"exception": map[string]interface{}{
+				"code": wErr.code,
+				"name": wErr.slug,
+			},
+		}
+	} else if errors.Is(err, errForbidden) {
+		gqlErr.Extensions = map[string]interface{}{"code": "FORBIDDEN"}
+	}
+
+	e.errors = append(e.errors, gqlErr)
+}
+
+// jsonValue converts value to the generic form encoding/json decodes into.
+func jsonValue(value interface{}) interface{} {
+	b, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("wikijstest: could not encode %T: %s", value, err))
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		panic(fmt.Sprintf("wikijstest: could not decode %T: %s", value, err))
+	}
+
+	return out
+}
+
+// responseResult is the ResponseStatus of a mutation, err may be nil.
+func responseResult(err error) map[string]interface{} {
+	if err == nil {
+		return map[string]interface{}{
+			"succeeded": true,
+			"errorCode": 0,
+			"slug":      "ok",
+			"message":   "Operation succeeded.",
+		}
+	}
+
+	var wErr *wikiError
+	if !errors.As(err, &wErr) {
+		wErr = &wikiError{1000, "GenericError", err.Error()}
+	}
+
+	return map[string]interface{}{
+		"succeeded": false,
+		"errorCode": wErr.code,
+		"slug":      wErr.slug,
+		"message":   wErr.message,
+	}
+}
+
+// defaultResponse wraps the result of a mutation without payload.
+func defaultResponse(err error) map[string]interface{} {
+	return map[string]interface{}{"responseResult": responseResult(err)}
+}
+
+func stringArg(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func boolArg(args map[string]interface{}, name string) bool {
+	b, _ := args[name].(bool)
+	return b
+}
+
+func intArg(args map[string]interface{}, name string) int {
+	switch v := args[name].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case json.Number:
+		i, _ := v.Int64()
+		return int(i)
+	}
+
+	return 0
+}
+
+func stringsArg(args map[string]interface{}, name string) []string {
+	list, _ := args[name].([]interface{})
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+func intsArg(args map[string]interface{}, name string) []int {
+	list, _ := args[name].([]interface{})
+	out := make([]int, 0, len(list))
+	for i := range list {
+		out = append(out, intArg(map[string]interface{}{"v": list[i]}, "v"))
+	}
+
+	return out
+}
+
+func objectsArg(args map[string]interface{}, name string) []map[string]interface{} {
+	list, _ := args[name].([]interface{})
+	out := make([]map[string]interface{}, 0, len(list))
+	for _, v := range list {
+		if m, ok := v.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+
+	return out
+}
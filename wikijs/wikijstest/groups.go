@@ -0,0 +1,128 @@
+package wikijstest
+
+func (s *Server) groupsQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"list": resolver(func(args map[string]interface{}) (interface{}, error) {
+			out := make([]interface{}, 0, len(s.store.Groups))
+			for _, g := range s.store.Groups {
+				v := jsonValue(g).(map[string]interface{})
+				v["userCount"] = len(s.groupUsers(g.Id))
+				out = append(out, v)
+			}
+			return out, nil
+		}),
+		"single": resolver(func(args map[string]interface{}) (interface{}, error) {
+			g := s.store.Group(intArg(args, "id"))
+			if g == nil {
+				return nil, nil
+			}
+			return s.groupValue(g), nil
+		}),
+	}
+}
+
+func (s *Server) groupsMutation() map[string]interface{} {
+	return map[string]interface{}{
+		"create": resolver(func(args map[string]interface{}) (interface{}, error) {
+			now := timestamp()
+			roles := []string{"read:pages", "read:assets", "read:comments", "write:comments"}
+			g := &Group{
+				Id:              s.store.nextID(),
+				Name:            stringArg(args, "name"),
+				RedirectOnLogin: "/",
+				Permissions:     roles,
+				PageRules: []PageRule{
+					{Id: "default", Match: "START", Roles: roles, Path: "", Locales: []string{}},
+				},
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			s.store.Groups = append(s.store.Groups, g)
+
+			return map[string]interface{}{"responseResult": responseResult(nil), "group": s.groupValue(g)}, nil
+		}),
+		"update": resolver(func(args map[string]interface{}) (interface{}, error) {
+			// wiki.js patches the group without checking that it exists
+			g := s.store.Group(intArg(args, "id"))
+			if g == nil {
+				return defaultResponse(nil), nil
+			}
+
+			var rules []PageRule
+			for _, r := range objectsArg(args, "pageRules") {
+				rules = append(rules, PageRule{
+					Id:      stringArg(r, "id"),
+					Deny:    boolArg(r, "deny"),
+					Match:   stringArg(r, "match"),
+					Roles:   stringsArg(r, "roles"),
+					Path:    stringArg(r, "path"),
+					Locales: stringsArg(r, "locales"),
+				})
+			}
+
+			g.Name = stringArg(args, "name")
+			g.RedirectOnLogin = stringArg(args, "redirectOnLogin")
+			g.Permissions = stringsArg(args, "permissions")
+			g.PageRules = rules
+			g.UpdatedAt = timestamp()
+
+			return defaultResponse(nil), nil
+		}),
+		"delete": resolver(func(args map[string]interface{}) (interface{}, error) {
+			id := intArg(args, "id")
+			if id == 1 || id == 2 {
+				return nil, errSystemGroupProtected
+			}
+			for i, g := range s.store.Groups {
+				if g.Id == id {
+					s.store.Groups = append(s.store.Groups[:i], s.store.Groups[i+1:]...)
+					break
+				}
+			}
+			return defaultResponse(nil), nil
+		}),
+		"assignUser": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, u := range s.store.Users {
+				if u.Id == intArg(args, "userId") {
+					u.Groups = append(u.Groups, intArg(args, "groupId"))
+				}
+			}
+			return defaultResponse(nil), nil
+		}),
+		"unassignUser": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for _, u := range s.store.Users {
+				if u.Id != intArg(args, "userId") {
+					continue
+				}
+				groups := u.Groups[:0]
+				for _, g := range u.Groups {
+					if g != intArg(args, "groupId") {
+						groups = append(groups, g)
+					}
+				}
+				u.Groups = groups
+			}
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+func (s *Server) groupValue(g *Group) map[string]interface{} {
+	v := jsonValue(g).(map[string]interface{})
+	v["users"] = s.groupUsers(g.Id)
+
+	return v
+}
+
+func (s *Server) groupUsers(id int) []*User {
+	users := []*User{}
+	for _, u := range s.store.Users {
+		for _, g := range u.Groups {
+			if g == id {
+				users = append(users, u)
+			}
+		}
+	}
+
+	return users
+}
@@ -0,0 +1,321 @@
+package wikijstest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *Server) pagesQuery() map[string]interface{} {
+	return map[string]interface{}{
+		"list": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.listPages(args), nil
+		}),
+		"single": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.Page(intArg(args, "id"))
+			if p == nil {
+				return nil, errPageNotFound
+			}
+			return pageValue(p), nil
+		}),
+		"singleByPath": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.PageByPath(stringArg(args, "path"), stringArg(args, "locale"))
+			if p == nil {
+				return nil, errPageNotFound
+			}
+			return pageValue(p), nil
+		}),
+		"checkConflicts": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.Page(intArg(args, "id"))
+			if p == nil {
+				return nil, errPageNotFound
+			}
+			checkout, err := time.Parse(time.RFC3339, stringArg(args, "checkoutDate"))
+			if err != nil {
+				return nil, errInputInvalid
+			}
+			updated, err := time.Parse(time.RFC3339, p.UpdatedAt)
+			if err != nil {
+				return nil, errInputInvalid
+			}
+			return updated.After(checkout), nil
+		}),
+		"conflictLatest": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.Page(intArg(args, "id"))
+			if p == nil {
+				return nil, errPageNotFound
+			}
+			return map[string]interface{}{
+				"id":          p.Id,
+				"authorId":    strconv.Itoa(p.AuthorId),
+				"authorName":  p.AuthorName,
+				"content":     p.Content,
+				"createdAt":   p.CreatedAt,
+				"description": p.Description,
+				"isPublished": p.IsPublished,
+				"locale":      p.Locale,
+				"path":        p.Path,
+				"tags":        jsonValue(p.Tags),
+				"title":       p.Title,
+				"updatedAt":   p.UpdatedAt,
+			}, nil
+		}),
+	}
+}
+
+func (s *Server) pagesMutation(user *User) map[string]interface{} {
+	return map[string]interface{}{
+		"create": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := &Page{
+				Id:        s.store.nextID(),
+				CreatedAt: timestamp(),
+			}
+			if user != nil {
+				p.CreatorId, p.CreatorName, p.CreatorEmail = user.Id, user.Name, user.Email
+			}
+			if err := s.savePage(p, args, user); err != nil {
+				return map[string]interface{}{"responseResult": responseResult(err)}, nil
+			}
+			s.store.Pages = append(s.store.Pages, p)
+
+			return map[string]interface{}{"responseResult": responseResult(nil), "page": pageValue(p)}, nil
+		}),
+		"update": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.Page(intArg(args, "id"))
+			if p == nil {
+				return map[string]interface{}{"responseResult": responseResult(errPageNotFound)}, nil
+			}
+
+			// Omitted arguments keep their value
+			updated := *p
+			if err := s.savePage(&updated, args, user); err != nil {
+				return map[string]interface{}{"responseResult": responseResult(err)}, nil
+			}
+			*p = updated
+
+			return map[string]interface{}{"responseResult": responseResult(nil), "page": pageValue(p)}, nil
+		}),
+		"convert": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.Page(intArg(args, "id"))
+			if p == nil {
+				return defaultResponse(errPageNotFound), nil
+			}
+
+			// The fake does not render pages, the content is kept as is
+			p.Editor = stringArg(args, "editor")
+			p.ContentType = contentType(p.Editor)
+			p.UpdatedAt = timestamp()
+
+			return defaultResponse(nil), nil
+		}),
+		"move": resolver(func(args map[string]interface{}) (interface{}, error) {
+			p := s.store.Page(intArg(args, "id"))
+			if p == nil {
+				return defaultResponse(errPageNotFound), nil
+			}
+
+			path := strings.Trim(stringArg(args, "destinationPath"), "/")
+			locale := stringArg(args, "destinationLocale")
+			if strings.ContainsAny(path, ". \\") || strings.Contains(path, "//") {
+				return defaultResponse(errPageIllegalPath), nil
+			}
+			if other := s.store.PageByPath(path, locale); other != nil && other.Id != p.Id {
+				return defaultResponse(errPagePathCollision), nil
+			}
+
+			p.Path, p.Locale = path, locale
+			p.Hash = pageHash(p.Locale, p.Path, p.PrivateNS)
+			p.UpdatedAt = timestamp()
+
+			return defaultResponse(nil), nil
+		}),
+		"delete": resolver(func(args map[string]interface{}) (interface{}, error) {
+			for i, p := range s.store.Pages {
+				if p.Id == intArg(args, "id") {
+					s.store.Pages = append(s.store.Pages[:i], s.store.Pages[i+1:]...)
+					return defaultResponse(nil), nil
+				}
+			}
+			return defaultResponse(errPageNotFound), nil
+		}),
+		"flushCache": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return defaultResponse(nil), nil
+		}),
+	}
+}
+
+// savePage applies the arguments of create and update to p.
+func (s *Server) savePage(p *Page, args map[string]interface{}, user *User) error {
+	for name, field := range map[string]*string{
+		"content":          &p.Content,
+		"description":      &p.Description,
+		"editor":           &p.Editor,
+		"locale":           &p.Locale,
+		"path":             &p.Path,
+		"publishEndDate":   &p.PublishEndDate,
+		"publishStartDate": &p.PublishStartDate,
+		"scriptCss":        &p.ScriptCss,
+		"scriptJs":         &p.ScriptJs,
+		"title":            &p.Title,
+	} {
+		if v, ok := args[name].(string); ok {
+			*field = v
+		}
+	}
+	if v, ok := args["isPrivate"].(bool); ok {
+		p.IsPrivate = v
+	}
+	if v, ok := args["isPublished"].(bool); ok {
+		p.IsPublished = v
+	}
+	if _, ok := args["tags"]; ok {
+		p.Tags = stringsArg(args, "tags")
+	}
+
+	p.Path = strings.Trim(p.Path, "/")
+	if strings.ContainsAny(p.Path, ". \\") || strings.Contains(p.Path, "//") {
+		return errPageIllegalPath
+	}
+	if strings.TrimSpace(p.Content) == "" {
+		return errPageEmptyContent
+	}
+	if other := s.store.PageByPath(p.Path, p.Locale); other != nil && other.Id != p.Id {
+		return errPageDuplicateCreate
+	}
+
+	p.Hash = pageHash(p.Locale, p.Path, p.PrivateNS)
+	p.ContentType = contentType(p.Editor)
+	p.Render = p.Content
+	p.UpdatedAt = timestamp()
+	if user != nil {
+		p.AuthorId, p.AuthorName, p.AuthorEmail = user.Id, user.Name, user.Email
+	}
+
+	return nil
+}
+
+// listPages mirrors the list resolver of server/graph/resolvers/page.js in
+// wiki.js. Like there, the limit applies before pages without all tags are
+// filtered out.
+func (s *Server) listPages(args map[string]interface{}) []interface{} {
+	locale := stringArg(args, "locale")
+	creatorId, authorId := intArg(args, "creatorId"), intArg(args, "authorId")
+
+	var pages []*Page
+	for _, p := range s.store.Pages {
+		if (locale == "" || p.Locale == locale) && matchesUsers(p, creatorId, authorId) {
+			pages = append(pages, p)
+		}
+	}
+
+	key := func(p *Page) string { return fmt.Sprintf("%010d", p.Id) }
+	switch stringArg(args, "orderBy") {
+	case "CREATED":
+		key = func(p *Page) string { return p.CreatedAt }
+	case "PATH":
+		key = func(p *Page) string { return p.Path }
+	case "TITLE":
+		key = func(p *Page) string { return p.Title }
+	case "UPDATED":
+		key = func(p *Page) string { return p.UpdatedAt }
+	}
+	desc := stringArg(args, "orderByDirection") == "DESC"
+	sort.SliceStable(pages, func(i, j int) bool {
+		if desc {
+			return key(pages[i]) > key(pages[j])
+		}
+		return key(pages[i]) < key(pages[j])
+	})
+
+	if limit := intArg(args, "limit"); limit > 0 && limit < len(pages) {
+		pages = pages[:limit]
+	}
+
+	tags := stringsArg(args, "tags")
+	out := []interface{}{}
+	for _, p := range pages {
+		if !hasTags(p, tags) {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"id":          p.Id,
+			"path":        p.Path,
+			"locale":      p.Locale,
+			"title":       p.Title,
+			"description": p.Description,
+			"contentType": contentType(p.Editor),
+			"isPublished": p.IsPublished,
+			"isPrivate":   p.IsPrivate,
+			"privateNS":   p.PrivateNS,
+			"createdAt":   p.CreatedAt,
+			"updatedAt":   p.UpdatedAt,
+			"tags":        jsonValue(p.Tags),
+		})
+	}
+
+	return out
+}
+
+// matchesUsers reports whether p was created by creatorId and last edited by
+// authorId. If both are given, either of them is enough.
+func matchesUsers(p *Page, creatorId, authorId int) bool {
+	if creatorId > 0 && authorId > 0 {
+		return p.CreatorId == creatorId || p.AuthorId == authorId
+	}
+
+	return (creatorId <= 0 || p.CreatorId == creatorId) && (authorId <= 0 || p.AuthorId == authorId)
+}
+
+func hasTags(p *Page, tags []string) bool {
+	for _, t := range tags {
+		found := false
+		for _, pt := range p.Tags {
+			found = found || pt == t
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pageValue returns p with its tags as PageTag objects.
+func pageValue(p *Page) map[string]interface{} {
+	v := jsonValue(p).(map[string]interface{})
+
+	tags := make([]interface{}, len(p.Tags))
+	for i, t := range p.Tags {
+		tags[i] = map[string]interface{}{
+			"id":        i + 1,
+			"tag":       t,
+			"title":     t,
+			"createdAt": p.CreatedAt,
+			"updatedAt": p.UpdatedAt,
+		}
+	}
+	v["tags"] = tags
+
+	return v
+}
+
+// pageHash mirrors generateHash of server/helpers/page.js in wiki.js.
+func pageHash(locale, path, privateNS string) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%s", locale, path, privateNS)))
+	return hex.EncodeToString(sum[:])
+}
+
+func contentType(editor string) string {
+	switch editor {
+	case "markdown":
+		return "markdown"
+	case "asciidoc":
+		return "asciidoc"
+	}
+
+	return "html"
+}
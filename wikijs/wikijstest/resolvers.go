@@ -0,0 +1,52 @@
+package wikijstest
+
+// query returns the root query object for a request of user, nil for guests.
+func (s *Server) query(user *User) map[string]interface{} {
+	return map[string]interface{}{
+		"authentication": protect(user, s.authenticationQuery()),
+		"groups":         protect(user, s.groupsQuery()),
+		"localization":   protect(user, s.localizationQuery()),
+		"pages":          protect(user, s.pagesQuery()),
+		"rendering":      protect(user, s.renderingQuery()),
+		"search":         protect(user, s.searchQuery()),
+		"site":           protect(user, s.siteQuery()),
+		"system":         protect(user, s.systemQuery()),
+		"theming":        protect(user, s.themingQuery()),
+	}
+}
+
+// mutation returns the root mutation object for a request of user, nil for
+// guests.
+func (s *Server) mutation(user *User) map[string]interface{} {
+	authentication := protect(user, s.authenticationMutation())
+	authentication["login"] = resolver(s.loginMutation)
+	authentication["loginTFA"] = resolver(s.loginTFAMutation)
+
+	return map[string]interface{}{
+		"authentication": authentication,
+		"groups":         protect(user, s.groupsMutation()),
+		"localization":   protect(user, s.localizationMutation()),
+		"pages":          protect(user, s.pagesMutation(user)),
+		"rendering":      protect(user, s.renderingMutation()),
+		"search":         protect(user, s.searchMutation()),
+		"site":           protect(user, s.siteMutation()),
+		"theming":        protect(user, s.themingMutation()),
+	}
+}
+
+// protect makes all fields of obj answer with Forbidden for guests, like the
+// @auth directive of wiki.js does.
+func protect(user *User, obj map[string]interface{}) map[string]interface{} {
+	if user != nil {
+		return obj
+	}
+
+	out := make(map[string]interface{}, len(obj))
+	for k := range obj {
+		out[k] = resolver(func(map[string]interface{}) (interface{}, error) {
+			return nil, errForbidden
+		})
+	}
+
+	return out
+}
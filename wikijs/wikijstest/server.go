@@ -0,0 +1,306 @@
+// Package wikijstest provides an in-process fake of the wiki.js GraphQL API
+// for tests. It serves wikijs/schema.graphql from an in-memory store and
+// implements the operations used by the terraform provider.
+package wikijstest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Credentials of the administrator account created by NewServer.
+const (
+	AdminEmail    = "admin@example.com"
+	AdminPassword = "password"
+)
+
+var schema = gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: wikijs.Schema})
+
+// Server is a fake wiki.js listening on a local port.
+type Server struct {
+	// URL is the site url of the fake wiki.js.
+	URL string
+
+	srv             *httptest.Server
+	sessionLifetime time.Duration
+
+	mu       sync.Mutex
+	store    *Store
+	sessions map[string]session
+	// pendingTFA maps continuation tokens to users that still need to
+	// provide a security code.
+	pendingTFA map[string]*User
+}
+
+type session struct {
+	user      *User
+	expiresAt time.Time
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithSetupPending starts the server in setup mode. The GraphQL API is not
+// available until the setup wizard is finalized.
+func WithSetupPending() Option {
+	return func(s *Server) {
+		s.store.Installed = false
+	}
+}
+
+// WithSessionLifetime sets how long session tokens stay valid, 30 minutes by
+// default.
+func WithSessionLifetime(d time.Duration) Option {
+	return func(s *Server) {
+		s.sessionLifetime = d
+	}
+}
+
+// WithAdminTOTPSecret requires the administrator to log in with two-factor
+// authentication using the base32 encoded TOTP secret.
+func WithAdminTOTPSecret(secret string) Option {
+	return func(s *Server) {
+		s.store.user(AdminEmail).TOTPSecret = secret
+	}
+}
+
+// NewServer starts a fake wiki.js with an administrator account using
+// AdminEmail and AdminPassword. Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		sessionLifetime: 30 * time.Minute,
+		store:           newStore(AdminEmail, AdminPassword),
+		sessions:        map[string]session{},
+		pendingTFA:      map[string]*User{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/graphql", s.handleGraphQL)
+	mux.HandleFunc("/finalize", s.handleFinalize)
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Update runs fn with exclusive access to the store, e.g. to prepare data or
+// to simulate changes made outside of terraform.
+func (s *Server) Update(fn func(store *Store)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.store)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, "ok")
+}
+
+func (s *Server) handleFinalize(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store.Installed || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req struct {
+		AdminEmail           string `json:"adminEmail"`
+		AdminPassword        string `json:"adminPassword"`
+		AdminPasswordConfirm string `json:"adminPasswordConfirm"`
+		SiteUrl              string `json:"siteUrl"`
+		Telemetry            bool   `json:"telemetry"`
+	}
+	result := map[string]interface{}{"ok": true}
+	switch err := json.NewDecoder(r.Body).Decode(&req); {
+	case err != nil:
+		result = map[string]interface{}{"ok": false, "error": err.Error()}
+	case req.AdminPassword != req.AdminPasswordConfirm || len(req.AdminPassword) < 6:
+		result = map[string]interface{}{"ok": false, "error": "Invalid admin password."}
+	default:
+		admin := s.store.Users[0]
+		admin.Email = req.AdminEmail
+		admin.Password = req.AdminPassword
+		s.store.SiteConfig["host"] = req.SiteUrl
+		s.store.Installed = true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// During setup wiki.js only serves the wizard
+	if !s.store.Installed {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "<html><body>Setup</body></html>")
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, errs := s.execute(s.authenticate(r), req.Query, req.OperationName, req.Variables)
+
+	resp := map[string]interface{}{"data": data}
+	if len(errs) > 0 {
+		resp["errors"] = errs
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) execute(user *User, query, operationName string, vars map[string]interface{}) (map[string]interface{}, gqlerror.List) {
+	doc, errs := gqlparser.LoadQuery(schema, query)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return nil, gqlerror.List{gqlerror.Errorf("unknown operation %q", operationName)}
+	}
+
+	coerced, err := validator.VariableValues(schema, op, vars)
+	if err != nil {
+		return nil, gqlerror.List{gqlerror.Wrap(err)}
+	}
+
+	var root map[string]interface{}
+	switch op.Operation {
+	case ast.Query:
+		root = s.query(user)
+	case ast.Mutation:
+		root = s.mutation(user)
+	default:
+		return nil, gqlerror.List{gqlerror.Errorf("%s is not supported", op.Operation)}
+	}
+
+	e := &executor{vars: coerced}
+	data := e.selectionSet(op.SelectionSet, root, nil)
+
+	return data, e.errors
+}
+
+// authenticate returns the user of the session cookie or API key, nil for
+// guests.
+func (s *Server) authenticate(r *http.Request) *User {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if !s.store.ApiEnabled {
+			return nil
+		}
+		for _, k := range s.store.ApiKeys {
+			if k.Key == token && !k.IsRevoked {
+				return s.store.Users[0]
+			}
+		}
+		return nil
+	}
+
+	cookie, err := r.Cookie("jwt")
+	if err != nil {
+		return nil
+	}
+	sess, ok := s.sessions[cookie.Value]
+	if !ok || time.Now().After(sess.expiresAt) {
+		return nil
+	}
+
+	return sess.user
+}
+
+// login starts a session for the user and returns its token.
+func (s *Server) login(user *User) string {
+	expiresAt := time.Now().Add(s.sessionLifetime)
+	token := newToken(map[string]interface{}{"id": user.Id, "email": user.Email, "exp": expiresAt.Unix()})
+	s.sessions[token] = session{user: user, expiresAt: expiresAt}
+
+	return token
+}
+
+// newToken returns a JWT shaped token with the given claims.
+func newToken(claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "none", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+
+	return base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + "." +
+		randomHex(16)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// validTOTP checks a RFC 6238 code allowing one step of clock drift.
+func validTOTP(secret, code string) bool {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "=")))
+	if err != nil {
+		return false
+	}
+
+	step := time.Now().Unix() / 30
+	for _, counter := range []int64{step - 1, step, step + 1} {
+		msg := make([]byte, 8)
+		binary.BigEndian.PutUint64(msg, uint64(counter))
+		mac := hmac.New(sha1.New, key)
+		mac.Write(msg)
+		sum := mac.Sum(nil)
+
+		offset := sum[len(sum)-1] & 0x0f
+		value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+		if fmt.Sprintf("%06d", value%1000000) == code {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,238 @@
+package wikijstest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/tyclipso/terraform-provider-wikijs/wikijs"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type cookieTransport struct {
+	jwt string
+}
+
+func (t *cookieTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.jwt != "" {
+		req.AddCookie(&http.Cookie{Name: "jwt", Value: t.jwt})
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func testClient(t *testing.T, srv *Server) (graphql.Client, *cookieTransport) {
+	t.Helper()
+
+	transport := &cookieTransport{}
+	client := graphql.NewClient(srv.URL+"/graphql", &http.Client{Transport: transport})
+
+	wresp, err := wikijs.Login(context.Background(), client, AdminEmail, AdminPassword, "local")
+	if err != nil {
+		t.Fatalf("login failed: %s", err)
+	}
+	if !wresp.Authentication.Login.ResponseResult.Succeeded {
+		t.Fatalf("login failed: %s", wresp.Authentication.Login.ResponseResult.Message)
+	}
+	transport.jwt = wresp.Authentication.Login.Jwt
+
+	return client, transport
+}
+
+func TestLogin(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := graphql.NewClient(srv.URL+"/graphql", http.DefaultClient)
+	wresp, err := wikijs.Login(context.Background(), client, AdminEmail, "wrong", "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wresp.Authentication.Login.ResponseResult.Succeeded || wresp.Authentication.Login.ResponseResult.ErrorCode != 1002 {
+		t.Errorf("expected AuthLoginFailed, got %+v", wresp.Authentication.Login.ResponseResult)
+	}
+
+	testClient(t, srv)
+}
+
+func TestGuestIsForbidden(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := graphql.NewClient(srv.URL+"/graphql", http.DefaultClient)
+	_, err := wikijs.GetApiState(context.Background(), client)
+
+	var list gqlerror.List
+	if !errors.As(err, &list) || list[0].Message != "Forbidden" {
+		t.Errorf("expected Forbidden error, got %v", err)
+	}
+}
+
+func TestPages(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client, _ := testClient(t, srv)
+	ctx := context.Background()
+
+	created, err := wikijs.CreatePage(ctx, client, "# Hello", "", "markdown", true, false, "en", "docs/hello", "", "", "", "", []string{"a"}, "Hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Pages.Create.ResponseResult.Succeeded {
+		t.Fatalf("could not create page: %s", created.Pages.Create.ResponseResult.Message)
+	}
+
+	duplicate, err := wikijs.CreatePage(ctx, client, "# Hello", "", "markdown", true, false, "en", "docs/hello", "", "", "", "", []string{}, "Hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if duplicate.Pages.Create.ResponseResult.ErrorCode != 6002 {
+		t.Errorf("expected PageDuplicateCreate, got %+v", duplicate.Pages.Create.ResponseResult)
+	}
+
+	page, err := wikijs.GetPage(ctx, client, created.Pages.Create.Page.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Pages.Single.Path != "docs/hello" || page.Pages.Single.CreatorEmail != AdminEmail || len(page.Pages.Single.Tags) != 1 {
+		t.Errorf("unexpected page %+v", page.Pages.Single)
+	}
+
+	if _, err := wikijs.DeletePage(ctx, client, page.Pages.Single.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = wikijs.GetPage(ctx, client, page.Pages.Single.Id)
+	var list gqlerror.List
+	if !errors.As(err, &list) || list[0].Message != "This page does not exist." {
+		t.Fatalf("expected page not found, got %v", err)
+	}
+	exception, _ := list[0].Extensions["exception"].(map[string]interface{})
+	if exception["code"] != float64(6003) {
+		t.Errorf("expected error code 6003, got %v", list[0].Extensions)
+	}
+}
+
+func TestMovePage(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client, _ := testClient(t, srv)
+	ctx := context.Background()
+
+	var ids []int
+	for _, path := range []string{"docs/hello", "docs/taken"} {
+		created, err := wikijs.CreatePage(ctx, client, "# Hello", "", "markdown", true, false, "en", path, "", "", "", "", []string{}, "Hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, created.Pages.Create.Page.Id)
+	}
+
+	collision, err := wikijs.MovePage(ctx, client, ids[0], "docs/taken", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collision.Pages.Move.ResponseResult.ErrorCode != 6006 {
+		t.Errorf("expected PagePathCollision, got %+v", collision.Pages.Move.ResponseResult)
+	}
+
+	moved, err := wikijs.MovePage(ctx, client, ids[0], "guides/hello", "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !moved.Pages.Move.ResponseResult.Succeeded {
+		t.Fatalf("could not move page: %s", moved.Pages.Move.ResponseResult.Message)
+	}
+
+	page, err := wikijs.GetPageByPath(ctx, client, "guides/hello", "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Pages.SingleByPath.Id != ids[0] || page.Pages.SingleByPath.Content != "# Hello" {
+		t.Errorf("expected page %d to keep its content at the new path, got %+v", ids[0], page.Pages.SingleByPath)
+	}
+}
+
+func TestListPages(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client, _ := testClient(t, srv)
+	ctx := context.Background()
+
+	for _, page := range []struct {
+		path, locale string
+		tags         []string
+	}{
+		{"docs/b", "en", []string{"runbook", "team"}},
+		{"docs/a", "en", []string{"runbook"}},
+		{"docs/c", "de", []string{"runbook", "team"}},
+	} {
+		if _, err := wikijs.CreatePage(ctx, client, "# Hello", "", "markdown", true, false, page.locale, page.path, "", "", "", "", page.tags, "Hello"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := wikijs.ListPages(ctx, client, 0, wikijs.PageOrderByPath, wikijs.PageOrderByDirectionDesc, []string{"runbook", "team"}, "", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, p := range list.Pages.List {
+		paths = append(paths, p.Locale+"/"+p.Path)
+	}
+	if strings.Join(paths, ",") != "de/docs/c,en/docs/b" {
+		t.Errorf("expected pages with both tags by path descending, got %v", paths)
+	}
+
+	list, err = wikijs.ListPages(ctx, client, 1, wikijs.PageOrderByPath, "", nil, "en", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Pages.List) != 1 || list.Pages.List[0].Path != "docs/a" {
+		t.Errorf("expected only docs/a, got %+v", list.Pages.List)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client, _ := testClient(t, srv)
+
+	srv.Update(func(store *Store) {
+		store.ThemeConfig["darkMode"] = true
+	})
+
+	wresp, err := wikijs.GetThemeConfig(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wresp.Theming.Config.DarkMode {
+		t.Errorf("expected dark mode from store")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	srv := NewServer(WithSetupPending())
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/graphql", "application/json", bytes.NewBufferString(`{"query":"{ __typename }"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected no GraphQL endpoint during setup, got %s", resp.Status)
+	}
+
+	resp, err = http.Post(srv.URL+"/finalize", "application/json", bytes.NewBufferString(`{"adminEmail":"`+AdminEmail+`","adminPassword":"`+AdminPassword+`","adminPasswordConfirm":"`+AdminPassword+`","siteUrl":"https://wiki.example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	testClient(t, srv)
+}
@@ -0,0 +1,360 @@
+package wikijstest
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Store is the in-memory state of the fake wiki.js. Field names follow the
+// GraphQL schema, so objects can be served as they are.
+type Store struct {
+	// Installed is false while the setup wizard has not been completed.
+	Installed bool
+	Version   string
+
+	Users         []*User
+	Groups        []*Group
+	Pages         []*Page
+	ApiKeys       []*ApiKey
+	ApiEnabled    bool
+	Strategies    []*AuthStrategy
+	Renderers     []*Renderer
+	SearchEngines []*SearchEngine
+	Themes        []*Theme
+	Locales       []*Locale
+
+	// SiteConfig, ThemeConfig and Localization hold the config objects by
+	// GraphQL field name.
+	SiteConfig   map[string]interface{}
+	ThemeConfig  map[string]interface{}
+	Localization map[string]interface{}
+
+	lastID int
+}
+
+// User is a wiki.js account.
+type User struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	ProviderKey string `json:"providerKey"`
+	IsSystem    bool   `json:"isSystem"`
+	IsActive    bool   `json:"isActive"`
+	CreatedAt   string `json:"createdAt"`
+	LastLoginAt string `json:"lastLoginAt"`
+	Groups      []int  `json:"-"`
+	Password    string `json:"-"`
+	// TOTPSecret enables two-factor authentication for the account.
+	TOTPSecret string `json:"-"`
+}
+
+// Group is a wiki.js user group.
+type Group struct {
+	Id              int        `json:"id"`
+	Name            string     `json:"name"`
+	IsSystem        bool       `json:"isSystem"`
+	RedirectOnLogin string     `json:"redirectOnLogin"`
+	Permissions     []string   `json:"permissions"`
+	PageRules       []PageRule `json:"pageRules"`
+	CreatedAt       string     `json:"createdAt"`
+	UpdatedAt       string     `json:"updatedAt"`
+}
+
+// PageRule is a page access rule of a group.
+type PageRule struct {
+	Id      string   `json:"id"`
+	Deny    bool     `json:"deny"`
+	Match   string   `json:"match"`
+	Roles   []string `json:"roles"`
+	Path    string   `json:"path"`
+	Locales []string `json:"locales"`
+}
+
+// Page is a wiki page.
+type Page struct {
+	Id               int      `json:"id"`
+	Path             string   `json:"path"`
+	Hash             string   `json:"hash"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	IsPrivate        bool     `json:"isPrivate"`
+	IsPublished      bool     `json:"isPublished"`
+	PrivateNS        string   `json:"privateNS"`
+	PublishStartDate string   `json:"publishStartDate"`
+	PublishEndDate   string   `json:"publishEndDate"`
+	Tags             []string `json:"-"`
+	Content          string   `json:"content"`
+	Render           string   `json:"render"`
+	ContentType      string   `json:"contentType"`
+	CreatedAt        string   `json:"createdAt"`
+	UpdatedAt        string   `json:"updatedAt"`
+	Editor           string   `json:"editor"`
+	Locale           string   `json:"locale"`
+	ScriptCss        string   `json:"scriptCss"`
+	ScriptJs         string   `json:"scriptJs"`
+	AuthorId         int      `json:"authorId"`
+	AuthorName       string   `json:"authorName"`
+	AuthorEmail      string   `json:"authorEmail"`
+	CreatorId        int      `json:"creatorId"`
+	CreatorName      string   `json:"creatorName"`
+	CreatorEmail     string   `json:"creatorEmail"`
+}
+
+// ApiKey is an API key, Key holds the full token.
+type ApiKey struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	KeyShort   string `json:"keyShort"`
+	Expiration string `json:"expiration"`
+	CreatedAt  string `json:"createdAt"`
+	UpdatedAt  string `json:"updatedAt"`
+	IsRevoked  bool   `json:"isRevoked"`
+	Key        string `json:"-"`
+	FullAccess bool   `json:"-"`
+	Group      int    `json:"-"`
+}
+
+// AuthStrategy is a configured authentication strategy.
+type AuthStrategy struct {
+	Key              string                 `json:"key"`
+	StrategyKey      string                 `json:"-"`
+	DisplayName      string                 `json:"displayName"`
+	Order            int                    `json:"order"`
+	IsEnabled        bool                   `json:"isEnabled"`
+	Config           map[string]interface{} `json:"-"`
+	SelfRegistration bool                   `json:"selfRegistration"`
+	DomainWhitelist  []string               `json:"domainWhitelist"`
+	AutoEnrollGroups []int                  `json:"autoEnrollGroups"`
+}
+
+// Renderer is a content renderer.
+type Renderer struct {
+	Key         string                 `json:"key"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Icon        string                 `json:"icon"`
+	DependsOn   string                 `json:"dependsOn"`
+	Input       string                 `json:"input"`
+	Output      string                 `json:"output"`
+	IsEnabled   bool                   `json:"isEnabled"`
+	Config      map[string]interface{} `json:"-"`
+}
+
+// SearchEngine is a search engine module.
+type SearchEngine struct {
+	Key         string                 `json:"key"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Logo        string                 `json:"logo"`
+	Website     string                 `json:"website"`
+	IsAvailable bool                   `json:"isAvailable"`
+	IsEnabled   bool                   `json:"isEnabled"`
+	Config      map[string]interface{} `json:"-"`
+}
+
+// Theme is an installed theme.
+type Theme struct {
+	Key    string `json:"key"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
+
+// Locale is a locale wiki.js can download.
+type Locale struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	NativeName   string `json:"nativeName"`
+	Availability int    `json:"availability"`
+	IsRTL        bool   `json:"isRTL"`
+	IsInstalled  bool   `json:"isInstalled"`
+	InstallDate  string `json:"installDate"`
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+// newStore returns the state of a freshly installed wiki.js.
+func newStore(email, password string) *Store {
+	now := timestamp()
+	s := &Store{
+		Installed: true,
+		Version:   "2.5.300",
+		Groups: []*Group{
+			{Id: 1, Name: "Administrators", IsSystem: true, RedirectOnLogin: "/", Permissions: []string{"manage:system"}, PageRules: []PageRule{}, CreatedAt: now, UpdatedAt: now},
+			{Id: 2, Name: "Guests", IsSystem: true, RedirectOnLogin: "/", Permissions: []string{"read:pages", "read:assets", "read:comments"}, PageRules: []PageRule{
+				{Id: "guest", Match: "START", Roles: []string{"read:pages", "read:assets", "read:comments"}, Path: "", Locales: []string{}},
+			}, CreatedAt: now, UpdatedAt: now},
+		},
+		Users: []*User{
+			{Id: 1, Name: "Administrator", Email: email, Password: password, ProviderKey: "local", IsActive: true, CreatedAt: now, Groups: []int{1}},
+			{Id: 2, Name: "Guest", Email: "guest@example.com", ProviderKey: "local", IsSystem: true, IsActive: true, CreatedAt: now, Groups: []int{2}},
+		},
+		Strategies: []*AuthStrategy{
+			{Key: "local", StrategyKey: "local", DisplayName: "Local", IsEnabled: true, Config: map[string]interface{}{}, DomainWhitelist: []string{}, AutoEnrollGroups: []int{}},
+		},
+		Renderers: []*Renderer{
+			{Key: "htmlCore", Title: "Core", Description: "Basic HTML Parser", Icon: "mdi-language-html5", Input: "html", Output: "html", IsEnabled: true, Config: map[string]interface{}{"absoluteLinks": false, "openExternalLinkNewTab": false, "relAttributeExternalLink": "noreferrer"}},
+			{Key: "markdownCore", Title: "Core", Description: "Basic Markdown Parser", Icon: "mdi-language-markdown", Input: "markdown", Output: "html", IsEnabled: true, Config: map[string]interface{}{"allowHTML": true, "linkify": true, "typographer": false}},
+			{Key: "markdownEmoji", Title: "Emoji", Description: "Convert tags to emojis", Icon: "mdi-sticker-emoji", DependsOn: "markdownCore", Input: "markdown", Output: "html", IsEnabled: true, Config: map[string]interface{}{}},
+		},
+		SearchEngines: []*SearchEngine{
+			{Key: "db", Title: "Database - Basic", Description: "Default basic database-based search engine.", Logo: "/_assets/svg/icon-search-database.svg", IsAvailable: true, IsEnabled: true, Config: map[string]interface{}{}},
+			{Key: "postgres", Title: "Database - PostgreSQL", Description: "Advanced PostgreSQL-based search engine.", Logo: "/_assets/svg/icon-postgresql.svg", Website: "https://www.postgresql.org/", IsAvailable: true, Config: map[string]interface{}{"dictLanguage": "english"}},
+		},
+		Themes: []*Theme{
+			{Key: "default", Title: "Default", Author: "requarks.io"},
+		},
+		Locales: []*Locale{
+			{Code: "en", Name: "English", NativeName: "English", Availability: 100, IsInstalled: true, InstallDate: now, CreatedAt: now, UpdatedAt: now},
+			{Code: "de", Name: "German", NativeName: "Deutsch", Availability: 100, CreatedAt: now, UpdatedAt: now},
+			{Code: "fr", Name: "French", NativeName: "Français", Availability: 100, CreatedAt: now, UpdatedAt: now},
+		},
+		SiteConfig: map[string]interface{}{
+			"host":                   "http://localhost",
+			"title":                  "Wiki.js",
+			"description":            "",
+			"robots":                 []interface{}{"index", "follow"},
+			"analyticsService":       "",
+			"analyticsId":            "",
+			"company":                "",
+			"contentLicense":         "",
+			"footerOverride":         "",
+			"logoUrl":                "https://static.requarks.io/logo/wikijs-butterfly.svg",
+			"pageExtensions":         "md, html, txt",
+			"authAutoLogin":          false,
+			"authEnforce2FA":         false,
+			"authHideLocal":          false,
+			"authLoginBgUrl":         "",
+			"authJwtAudience":        "urn:wiki.js",
+			"authJwtExpiration":      "30m",
+			"authJwtRenewablePeriod": "14d",
+			"editFab":                true,
+			"editMenuBar":            false,
+			"editMenuBtn":            true,
+			"editMenuExternalBtn":    true,
+			"editMenuExternalName":   "GitHub",
+			"editMenuExternalIcon":   "mdi-github",
+			"editMenuExternalUrl":    "https://github.com/org/repo/blob/main/{filename}",
+			"featurePageRatings":     true,
+			"featurePageComments":    true,
+			"featurePersonalWikis":   true,
+			"securityOpenRedirect":   true,
+			"securityIframe":         true,
+			"securityReferrerPolicy": true,
+			"securityTrustProxy":     true,
+			"securitySRI":            true,
+			"securityHSTS":           false,
+			"securityHSTSDuration":   300,
+			"securityCSP":            false,
+			"securityCSPDirectives":  "",
+			"uploadMaxFileSize":      5242880,
+			"uploadMaxFiles":         10,
+			"uploadScanSVG":          true,
+			"uploadForceDownload":    true,
+		},
+		ThemeConfig: map[string]interface{}{
+			"theme":       "default",
+			"iconset":     "mdi",
+			"darkMode":    false,
+			"tocPosition": "left",
+			"injectCSS":   "",
+			"injectHead":  "",
+			"injectBody":  "",
+		},
+		Localization: map[string]interface{}{
+			"locale":      "en",
+			"autoUpdate":  false,
+			"namespacing": false,
+			"namespaces":  []interface{}{},
+		},
+		lastID: 2,
+	}
+
+	return s
+}
+
+// nextID returns a new id for groups, pages and API keys.
+func (s *Store) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+// Page returns the page with the given id or nil.
+func (s *Store) Page(id int) *Page {
+	for _, p := range s.Pages {
+		if p.Id == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// PageByPath returns the page at path in locale or nil.
+func (s *Store) PageByPath(path, locale string) *Page {
+	for _, p := range s.Pages {
+		if p.Path == path && p.Locale == locale {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Group returns the group with the given id or nil.
+func (s *Store) Group(id int) *Group {
+	for _, g := range s.Groups {
+		if g.Id == id {
+			return g
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) user(email string) *User {
+	for _, u := range s.Users {
+		if u.Email == email {
+			return u
+		}
+	}
+
+	return nil
+}
+
+// configValues returns a module config the way wiki.js lists it: sorted by
+// key with the value wrapped in a JSON object.
+func configValues(config map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		value, _ := json.Marshal(map[string]interface{}{"value": config[k]})
+		out = append(out, map[string]interface{}{"key": k, "value": string(value)})
+	}
+
+	return out
+}
+
+// configInput decodes a module config sent as key and {"v": value} pairs.
+func configInput(input map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{}
+	for _, kv := range objectsArg(input, "config") {
+		var value struct {
+			V interface{} `json:"v"`
+		}
+		if err := json.Unmarshal([]byte(stringArg(kv, "value")), &value); err == nil {
+			config[stringArg(kv, "key")] = value.V
+		}
+	}
+
+	return config
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}